@@ -0,0 +1,18 @@
+terraform {
+  required_version = ">= 1.14.0"
+
+  required_providers {
+    mythicbeasts = {
+      source = "paultibbetts/mythicbeasts"
+    }
+  }
+}
+
+# Reboot on demand with:
+#   terraform apply -invoke=action.mythicbeasts_pi_reboot.example
+action "mythicbeasts_pi_reboot" "example" {
+  config {
+    identifier = "example"
+    timeout    = "10m"
+  }
+}
@@ -0,0 +1,26 @@
+terraform {
+  required_version = ">= 1.14.0"
+
+  required_providers {
+    mythicbeasts = {
+      source = "paultibbetts/mythicbeasts"
+    }
+  }
+}
+
+# Reboot on demand with:
+#   terraform apply -invoke=action.mythicbeasts_vps_power.reboot
+action "mythicbeasts_vps_power" "reboot" {
+  config {
+    identifier = "example"
+    power      = "reboot"
+  }
+}
+
+action "mythicbeasts_vps_power" "shutdown" {
+  config {
+    identifier   = "example"
+    power        = "shutdown"
+    grace_period = 60
+  }
+}
@@ -0,0 +1,17 @@
+terraform {
+  required_version = ">= 1.11.0"
+
+  required_providers {
+    mythicbeasts = {
+      source = "paultibbetts/mythicbeasts"
+    }
+  }
+}
+
+data "mythicbeasts_pi" "example" {
+  identifier = "example"
+}
+
+output "pi" {
+  value = data.mythicbeasts_pi.example
+}
@@ -0,0 +1,23 @@
+terraform {
+  required_version = ">= 1.11.0"
+
+  required_providers {
+    mythicbeasts = {
+      source = "paultibbetts/mythicbeasts"
+    }
+  }
+}
+
+data "mythicbeasts_proxy_endpoints" "all" {}
+
+data "mythicbeasts_proxy_endpoints" "example" {
+  domain = "example.com"
+}
+
+output "all_proxy_endpoints" {
+  value = data.mythicbeasts_proxy_endpoints.all.endpoints
+}
+
+output "example_proxied_hostnames" {
+  value = distinct([for endpoint in data.mythicbeasts_proxy_endpoints.example.endpoints : "${endpoint.hostname}.${endpoint.domain}"])
+}
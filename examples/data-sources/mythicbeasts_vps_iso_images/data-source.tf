@@ -0,0 +1,24 @@
+terraform {
+  required_version = ">= 1.11.0"
+
+  required_providers {
+    mythicbeasts = {
+      source = "paultibbetts/mythicbeasts"
+    }
+  }
+}
+
+data "mythicbeasts_vps_iso_images" "all" {}
+
+data "mythicbeasts_vps_iso_images" "debian" {
+  architecture = "x86_64"
+  name_regex   = "^debian-"
+}
+
+output "all_iso_images" {
+  value = data.mythicbeasts_vps_iso_images.all
+}
+
+output "debian_iso_images" {
+  value = data.mythicbeasts_vps_iso_images.debian
+}
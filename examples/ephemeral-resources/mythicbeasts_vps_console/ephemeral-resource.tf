@@ -0,0 +1,13 @@
+terraform {
+  required_version = ">= 1.11.0"
+
+  required_providers {
+    mythicbeasts = {
+      source = "paultibbetts/mythicbeasts"
+    }
+  }
+}
+
+ephemeral "mythicbeasts_vps_console" "example" {
+  identifier = "example"
+}
@@ -13,8 +13,29 @@ resource "mythicbeasts_pi" "example" {
   disk_size    = 10
   model        = 4
   os_image     = "rpi-bookworm-arm64"
-  ssh_key      = "ssh-ed25519 ..."
   wait_for_dns = true
   memory       = 4096
+
+  ssh_keys = [
+    "ssh-ed25519 AAAA... alice@example.com",
+    "ssh-ed25519 AAAA... bob@example.com",
+  ]
+
+  dns_wait = {
+    timeout = "15m"
+  }
+
+  delete_proxy_endpoints_on_destroy = true
+
+  proxy_endpoints = [
+    {
+      domain   = "example.com"
+      hostname = "www"
+    },
+  ]
+
+  timeouts {
+    create = "30m"
+  }
 }
 
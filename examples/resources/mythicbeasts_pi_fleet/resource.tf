@@ -0,0 +1,30 @@
+terraform {
+  required_version = ">= 1.11.0"
+
+  required_providers {
+    mythicbeasts = {
+      source = "paultibbetts/mythicbeasts"
+    }
+  }
+}
+
+resource "mythicbeasts_pi_fleet" "example" {
+  identifier_prefix = "example"
+  size              = 3
+  model             = 4
+  memory            = 4096
+  os_image          = "rpi-bookworm-arm64"
+  concurrency       = 3
+
+  ssh_keys = [
+    "ssh-ed25519 AAAA... alice@example.com",
+  ]
+
+  timeouts {
+    create = "45m"
+  }
+}
+
+output "example_fleet_ips" {
+  value = { for identifier, member in mythicbeasts_pi_fleet.example.members : identifier => member.ip }
+}
@@ -0,0 +1,15 @@
+import {
+  to = mythicbeasts_proxy_endpoint_set.example
+  id = "example.com/www"
+}
+
+resource "mythicbeasts_proxy_endpoint_set" "example" {
+  domain   = "example.com"
+  hostname = "www"
+
+  endpoints = [
+    {
+      address = "2001:db8::1"
+    },
+  ]
+}
@@ -0,0 +1 @@
+terraform import mythicbeasts_proxy_endpoint_set.example 'example.com/www'
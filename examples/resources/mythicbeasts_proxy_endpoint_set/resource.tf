@@ -0,0 +1,38 @@
+terraform {
+  required_version = ">= 1.11.0"
+
+  required_providers {
+    mythicbeasts = {
+      source = "paultibbetts/mythicbeasts"
+    }
+  }
+}
+
+resource "mythicbeasts_pi" "blue" {
+  identifier = "blue"
+  model      = 4
+  os_image   = "rpi-bookworm-arm64"
+  ssh_key    = "ssh-ed25519 ..."
+}
+
+resource "mythicbeasts_pi" "green" {
+  identifier = "green"
+  model      = 4
+  os_image   = "rpi-bookworm-arm64"
+  ssh_key    = "ssh-ed25519 ..."
+}
+
+resource "mythicbeasts_proxy_endpoint_set" "www" {
+  domain   = "example.com"
+  hostname = "www"
+
+  endpoints = [
+    {
+      address = mythicbeasts_pi.blue.ip
+    },
+    {
+      address        = mythicbeasts_pi.green.ip
+      proxy_protocol = true
+    },
+  ]
+}
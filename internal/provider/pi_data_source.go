@@ -0,0 +1,166 @@
+// Copyright IBM Corp. 2021, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/paultibbetts/mythicbeasts-client-go"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &piDataSource{}
+	_ datasource.DataSourceWithConfigure = &piDataSource{}
+)
+
+// NewPiDataSource is a helper function to simplify the provider implementation.
+func NewPiDataSource() datasource.DataSource {
+	return &piDataSource{}
+}
+
+// piDataSource is the data source implementation.
+type piDataSource struct {
+	client *mythicbeasts.Client
+}
+
+type piDataSourceModel struct {
+	Identifier types.String `tfsdk:"identifier"`
+	DiskSize   types.Int64  `tfsdk:"disk_size"`
+	Model      types.Int64  `tfsdk:"model"`
+	Memory     types.Int64  `tfsdk:"memory"`
+	CPUSpeed   types.Int64  `tfsdk:"cpu_speed"`
+	NICSpeed   types.Int64  `tfsdk:"nic_speed"`
+	IP         types.String `tfsdk:"ip"`
+	SSHPort    types.Int64  `tfsdk:"ssh_port"`
+	Location   types.String `tfsdk:"location"`
+	PowerState types.String `tfsdk:"power_state"`
+}
+
+// Metadata returns the data source type name.
+func (d *piDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_pi"
+}
+
+// Schema defines the schema for the data source.
+func (d *piDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Returns an existing Raspberry Pi server by identifier, such as one managed outside this configuration. See the [`mythicbeasts_pi` resource](../resources/pi) to manage a Pi.",
+		Attributes: map[string]schema.Attribute{
+			"identifier": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Identifier of the Pi",
+			},
+			"disk_size": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "Disk size in GB",
+			},
+			"model": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "Raspberry Pi model",
+			},
+			"memory": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "RAM size in MB",
+			},
+			"cpu_speed": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "CPU speed in MHz",
+			},
+			"nic_speed": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "NIC speed in Mbps",
+			},
+			"ip": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "IPv6 address for server",
+			},
+			"ssh_port": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "Port for accessing SSH via IPv4 relay. Server is accessible on `ssh.{identifier}.hostedpi.com`.",
+			},
+			"location": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Data centre in which server is located",
+			},
+			"power_state": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Whether the Pi is powered `on` or `off`",
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *piDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config piDataSourceModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	server, err := d.client.Pi().Get(ctx, config.Identifier.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to read Mythic Beasts Pi",
+			"Could not read Pi "+config.Identifier.String()+": "+err.Error(),
+		)
+		return
+	}
+
+	var pi PiResourceModel
+	if err := pi.readServer(server); err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to read Mythic Beasts Pi",
+			fmt.Sprintf("Could not read Pi %s: %s", config.Identifier.String(), err.Error()),
+		)
+		return
+	}
+
+	state := piDataSourceModel{
+		Identifier: config.Identifier,
+		DiskSize:   pi.DiskSize,
+		Model:      pi.Model,
+		Memory:     pi.Memory,
+		CPUSpeed:   pi.CPUSpeed,
+		NICSpeed:   pi.NICSpeed,
+		IP:         pi.IP,
+		SSHPort:    pi.SSHPort,
+		Location:   pi.Location,
+		PowerState: pi.PowerState,
+	}
+
+	// Set state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *piDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*mythicbeasts.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *mythicbeasts.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
@@ -0,0 +1,50 @@
+// Copyright IBM Corp. 2021, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/compare"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+func TestAccPiDataSource(t *testing.T) {
+	piIdentifier := testAccIdentifier("tfpd", 20)
+	resourceAddress := "mythicbeasts_pi." + piIdentifier
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: testAccPiResourceConfig(piIdentifier, piSSHKey, 1) + `
+data "mythicbeasts_pi" "test" {
+  identifier = ` + resourceAddress + `.identifier
+}
+`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.CompareValuePairs(
+						"data.mythicbeasts_pi.test", tfjsonpath.New("ip"),
+						resourceAddress, tfjsonpath.New("ip"),
+						compare.ValuesSame(),
+					),
+					statecheck.CompareValuePairs(
+						"data.mythicbeasts_pi.test", tfjsonpath.New("ssh_port"),
+						resourceAddress, tfjsonpath.New("ssh_port"),
+						compare.ValuesSame(),
+					),
+					statecheck.CompareValuePairs(
+						"data.mythicbeasts_pi.test", tfjsonpath.New("location"),
+						resourceAddress, tfjsonpath.New("location"),
+						compare.ValuesSame(),
+					),
+				},
+			},
+		},
+	})
+}
@@ -0,0 +1,128 @@
+// Copyright IBM Corp. 2021, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	defaultPiDNSWaitTimeout = "10m"
+	piDNSWaitPollInterval   = 10 * time.Second
+	piDNSDomain             = "hostedpi.com"
+)
+
+// PiDNSWaitModel maps the dns_wait attribute of the Pi resource.
+type PiDNSWaitModel struct {
+	Resolver types.String `tfsdk:"resolver"`
+	Timeout  types.String `tfsdk:"timeout"`
+}
+
+var piDNSWaitAttrTypes = map[string]attr.Type{
+	"resolver": types.StringType,
+	"timeout":  types.StringType,
+}
+
+// dnsResolver looks up the addresses of a host. It is satisfied by
+// *net.Resolver.
+type dnsResolver interface {
+	LookupIP(ctx context.Context, network string, host string) ([]net.IP, error)
+}
+
+// newDNSResolver returns a resolver that queries the DNS server at address,
+// or the system resolver when address is empty. A missing port defaults to
+// 53.
+func newDNSResolver(address string) dnsResolver {
+	if address == "" {
+		return net.DefaultResolver
+	}
+
+	if _, _, err := net.SplitHostPort(address); err != nil {
+		address = net.JoinHostPort(address, "53")
+	}
+
+	return &net.Resolver{
+		PreferGo: true,
+		Dial: func(ctx context.Context, network string, _ string) (net.Conn, error) {
+			var dialer net.Dialer
+			return dialer.DialContext(ctx, network, address)
+		},
+	}
+}
+
+// piDNSNames returns the names the API publishes for a Pi.
+func piDNSNames(identifier string) []string {
+	return []string{
+		identifier + "." + piDNSDomain,
+		"ssh." + identifier + "." + piDNSDomain,
+	}
+}
+
+// checkPiDNS returns an error unless every name has an AAAA record for the
+// Pi's address and an A record for the IPv4 proxy.
+func checkPiDNS(ctx context.Context, resolver dnsResolver, names []string, ip string) error {
+	want := net.ParseIP(ip)
+	if want == nil {
+		return fmt.Errorf("invalid IPv6 address %q", ip)
+	}
+
+	for _, name := range names {
+		ipv6, err := resolver.LookupIP(ctx, "ip6", name)
+		if err != nil {
+			return fmt.Errorf("looking up AAAA for %s: %w", name, err)
+		}
+
+		found := false
+		for _, got := range ipv6 {
+			if got.Equal(want) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("AAAA for %s does not include %s", name, ip)
+		}
+
+		ipv4, err := resolver.LookupIP(ctx, "ip4", name)
+		if err != nil {
+			return fmt.Errorf("looking up A for %s: %w", name, err)
+		}
+		if len(ipv4) == 0 {
+			return fmt.Errorf("no A record for %s", name)
+		}
+	}
+
+	return nil
+}
+
+// waitForPiDNS polls the resolver until the Pi's records match its address
+// or the context is cancelled.
+func waitForPiDNS(ctx context.Context, resolver dnsResolver, identifier string, ip string, interval time.Duration) error {
+	names := piDNSNames(identifier)
+
+	for {
+		err := checkPiDNS(ctx, resolver, names, ip)
+		if err == nil {
+			return nil
+		}
+
+		tflog.Debug(ctx, "Waiting for Pi DNS records", map[string]interface{}{
+			"identifier": identifier,
+			"error":      err.Error(),
+		})
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("timed out waiting for DNS records: %w", err)
+		case <-time.After(interval):
+		}
+	}
+}
@@ -0,0 +1,139 @@
+// Copyright IBM Corp. 2021, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"net"
+	"net/netip"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+// testDNSServer answers A and AAAA queries from a map of records over UDP.
+type testDNSServer struct {
+	conn net.PacketConn
+
+	mu      sync.Mutex
+	records map[string][]netip.Addr
+}
+
+func newTestDNSServer(t *testing.T) *testDNSServer {
+	t.Helper()
+
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("unable to listen: %s", err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+
+	server := &testDNSServer{conn: conn, records: map[string][]netip.Addr{}}
+	go server.serve()
+
+	return server
+}
+
+func (s *testDNSServer) set(name string, addresses ...string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.records[name] = nil
+	for _, address := range addresses {
+		s.records[name] = append(s.records[name], netip.MustParseAddr(address))
+	}
+}
+
+func (s *testDNSServer) serve() {
+	buf := make([]byte, 512)
+
+	for {
+		n, addr, err := s.conn.ReadFrom(buf)
+		if err != nil {
+			return
+		}
+
+		var msg dnsmessage.Message
+		if err := msg.Unpack(buf[:n]); err != nil || len(msg.Questions) == 0 {
+			continue
+		}
+
+		answer := s.answer(msg)
+		reply, err := answer.Pack()
+		if err != nil {
+			continue
+		}
+
+		_, _ = s.conn.WriteTo(reply, addr)
+	}
+}
+
+func (s *testDNSServer) answer(query dnsmessage.Message) dnsmessage.Message {
+	question := query.Questions[0]
+	name := strings.TrimSuffix(question.Name.String(), ".")
+
+	reply := dnsmessage.Message{
+		Header:    dnsmessage.Header{ID: query.ID, Response: true, Authoritative: true},
+		Questions: query.Questions,
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, address := range s.records[name] {
+		header := dnsmessage.ResourceHeader{Name: question.Name, Class: dnsmessage.ClassINET, TTL: 60}
+
+		switch {
+		case question.Type == dnsmessage.TypeA && address.Is4():
+			header.Type = dnsmessage.TypeA
+			reply.Answers = append(reply.Answers, dnsmessage.Resource{Header: header, Body: &dnsmessage.AResource{A: address.As4()}})
+		case question.Type == dnsmessage.TypeAAAA && address.Is6():
+			header.Type = dnsmessage.TypeAAAA
+			reply.Answers = append(reply.Answers, dnsmessage.Resource{Header: header, Body: &dnsmessage.AAAAResource{AAAA: address.As16()}})
+		}
+	}
+
+	return reply
+}
+
+func TestWaitForPiDNSAgainstLocalServer(t *testing.T) {
+	server := newTestDNSServer(t)
+	resolver := newDNSResolver(server.conn.LocalAddr().String())
+
+	const ip = "2a00:1098:8:1::1"
+
+	// Only the forward record is published, so the wait must time out.
+	server.set("example.hostedpi.com", ip, "93.93.130.1")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+
+	if err := waitForPiDNS(ctx, resolver, "example", ip, 10*time.Millisecond); err == nil {
+		t.Fatal("expected a timeout while ssh.example.hostedpi.com is missing")
+	}
+
+	server.set("ssh.example.hostedpi.com", ip, "93.93.130.2")
+
+	ctx, cancel = context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := waitForPiDNS(ctx, resolver, "example", ip, 10*time.Millisecond); err != nil {
+		t.Fatalf("expected the records to resolve, got %s", err)
+	}
+}
+
+func TestCheckPiDNSRejectsWrongAddress(t *testing.T) {
+	server := newTestDNSServer(t)
+	resolver := newDNSResolver(server.conn.LocalAddr().String())
+
+	server.set("example.hostedpi.com", "2a00:1098:8:1::2", "93.93.130.1")
+
+	err := checkPiDNS(context.Background(), resolver, []string{"example.hostedpi.com"}, "2a00:1098:8:1::1")
+	if err == nil || !strings.Contains(err.Error(), "does not include") {
+		t.Fatalf("expected a mismatched AAAA error, got %v", err)
+	}
+}
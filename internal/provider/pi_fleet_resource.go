@@ -0,0 +1,822 @@
+// Copyright IBM Corp. 2021, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/paultibbetts/mythicbeasts-client-go"
+	mbPi "github.com/paultibbetts/mythicbeasts-client-go/pi"
+)
+
+const (
+	defaultPiFleetConcurrency = 5
+	piFleetMemberReady        = "ready"
+	piFleetMemberFailed       = "failed"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource               = &PiFleetResource{}
+	_ resource.ResourceWithConfigure  = &PiFleetResource{}
+	_ resource.ResourceWithModifyPlan = &PiFleetResource{}
+)
+
+// NewPiFleetResource is a helper function to simplify the provider implementation.
+func NewPiFleetResource() resource.Resource {
+	return &PiFleetResource{}
+}
+
+// PiFleetResource is the resource implementation.
+type PiFleetResource struct {
+	client *mythicbeasts.Client
+}
+
+// PiFleetResourceModel maps the resource schema data.
+type PiFleetResourceModel struct {
+	ID               types.String `tfsdk:"id"`
+	IdentifierPrefix types.String `tfsdk:"identifier_prefix"`
+	Size             types.Int64  `tfsdk:"size"`
+	Model            types.Int64  `tfsdk:"model"`
+	Memory           types.Int64  `tfsdk:"memory"`
+	CPUSpeed         types.Int64  `tfsdk:"cpu_speed"`
+	DiskSize         types.Int64  `tfsdk:"disk_size"`
+	OSImage          types.String `tfsdk:"os_image"`
+	SSHKeys          types.List   `tfsdk:"ssh_keys"`
+	Concurrency      types.Int64  `tfsdk:"concurrency"`
+	Members          types.Map    `tfsdk:"members"`
+
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
+}
+
+// PiFleetMemberModel maps an entry of the members attribute of the Pi fleet resource.
+type PiFleetMemberModel struct {
+	IP      types.String `tfsdk:"ip"`
+	SSHPort types.Int64  `tfsdk:"ssh_port"`
+	Status  types.String `tfsdk:"status"`
+	Error   types.String `tfsdk:"error"`
+}
+
+var piFleetMemberAttrTypes = map[string]attr.Type{
+	"ip":       types.StringType,
+	"ssh_port": types.Int64Type,
+	"status":   types.StringType,
+	"error":    types.StringType,
+}
+
+var piFleetMemberType = types.ObjectType{AttrTypes: piFleetMemberAttrTypes}
+
+// Metadata returns the resource type name.
+func (r *PiFleetResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_pi_fleet"
+}
+
+// Schema defines the schema for the resource.
+func (r *PiFleetResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages a fleet of identical Raspberry Pi servers.\n\n" +
+			"Members are named `{identifier_prefix}-{n}`, counting from 1. Growing the fleet adds members and shrinking it removes the highest numbered ones. " +
+			"Changing `model`, `memory`, `cpu_speed`, `disk_size` or `os_image` replaces every member; changing `ssh_keys` updates the keys on every member in place.\n\n" +
+			"A member that fails to be created is recorded in `members` with a `failed` status and is retried on the next apply, without affecting the other members.\n\n" +
+			"See the [`mythicbeasts_pi` resource](../resources/pi) to manage a single Pi.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Identifier of the fleet; the same as `identifier_prefix`.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"identifier_prefix": schema.StringAttribute{
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.LengthBetween(2, 16),
+					stringvalidator.RegexMatches(
+						regexp.MustCompile(`^[a-zA-Z0-9\-]*$`),
+						"must consist only of alphanumerics and -",
+					),
+				},
+				MarkdownDescription: "Prefix for the identifiers of the members. Must consist only of alphanumerics and `-`, and be at most 16 characters long so that the member identifiers fit.",
+			},
+			"size": schema.Int64Attribute{
+				Required: true,
+				Validators: []validator.Int64{
+					int64validator.Between(0, 999),
+				},
+				MarkdownDescription: "Number of Pis in the fleet.",
+			},
+			"model": schema.Int64Attribute{
+				Computed:            true,
+				Optional:            true,
+				Default:             int64default.StaticInt64(3),
+				MarkdownDescription: "Raspberry Pi model, such as 3 or 4. Must be one of the models returned by the [`mythicbeasts_pi_models` data source](../data-sources/pi_models).",
+			},
+			"memory": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: "RAM size in MB. Defaults to the lowest available spec matching all of `model`, `memory` and `cpu_speed`.",
+			},
+			"cpu_speed": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: "CPU speed in MHz. Defaults to the lowest available spec matching all of `model`, `memory` and `cpu_speed`.",
+			},
+			"disk_size": schema.Int64Attribute{
+				Computed:            true,
+				Optional:            true,
+				Default:             int64default.StaticInt64(10),
+				MarkdownDescription: "Disk space size, in GB. Must be a multiple of 10",
+				Validators: []validator.Int64{
+					MultipleOfTen(),
+				},
+			},
+			"os_image": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Operating system image; see the [`mythicbeasts_pi_operating_systems` data source](../data-sources/pi_operating_systems) for valid values",
+			},
+			"ssh_keys": schema.ListAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+					listvalidator.ValueStringsAre(SSHPublicKey()),
+				},
+				MarkdownDescription: "Public SSH keys to be added to /root/.ssh/authorized_keys on every member, one per entry. Repeated keys are only installed once.",
+			},
+			"concurrency": schema.Int64Attribute{
+				Computed: true,
+				Optional: true,
+				Default:  int64default.StaticInt64(defaultPiFleetConcurrency),
+				Validators: []validator.Int64{
+					int64validator.Between(1, 20),
+				},
+				MarkdownDescription: "Number of members created, replaced or deleted at the same time. Members are replaced a batch of this many at a time, so the rest of the fleet keeps running.\nDefault: `" + strconv.Itoa(defaultPiFleetConcurrency) + "`",
+			},
+			"members": schema.MapNestedAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.UseStateForUnknown(),
+				},
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"ip": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "IPv6 address for server",
+						},
+						"ssh_port": schema.Int64Attribute{
+							Computed:            true,
+							MarkdownDescription: "Port for accessing SSH via IPv4 relay. Server is accessible on `ssh.{identifier}.hostedpi.com`.",
+						},
+						"status": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "`ready`, or `failed` if the member could not be created",
+						},
+						"error": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Why the member could not be created, if it failed",
+						},
+					},
+				},
+				MarkdownDescription: "Members of the fleet, keyed by identifier.",
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create:            true,
+				Update:            true,
+				Delete:            true,
+				CreateDescription: "How long to wait for the members to be provisioned. Default: `30m`",
+				UpdateDescription: "How long to wait for members to be added or replaced. Default: `30m`",
+				DeleteDescription: "How long to wait for the members to be deleted. Default: `10m`",
+			}),
+		},
+	}
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *PiFleetResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*mythicbeasts.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *mythicbeasts.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+// piFleetMemberIdentifiers returns the identifiers of a fleet of the given size.
+func piFleetMemberIdentifiers(prefix string, size int64) []string {
+	identifiers := make([]string, 0, size)
+	for i := int64(1); i <= size; i++ {
+		identifiers = append(identifiers, fmt.Sprintf("%s-%d", prefix, i))
+	}
+
+	return identifiers
+}
+
+// forEachConcurrently calls fn for each identifier, running at most limit
+// calls at a time, and returns the error from each call that failed.
+func forEachConcurrently(ctx context.Context, identifiers []string, limit int, fn func(context.Context, string) error) map[string]error {
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	errs := map[string]error{}
+	sem := make(chan struct{}, max(limit, 1))
+
+	for _, identifier := range identifiers {
+		wg.Add(1)
+		sem <- struct{}{}
+
+		go func(identifier string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			if err := fn(ctx, identifier); err != nil {
+				mu.Lock()
+				errs[identifier] = err
+				mu.Unlock()
+			}
+		}(identifier)
+	}
+
+	wg.Wait()
+
+	return errs
+}
+
+// sortedKeys returns the keys of the map in order.
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+func readyPiFleetMember(server mbPi.Server) (PiFleetMemberModel, error) {
+	ip, err := normalizeIPv6(server.IP)
+	if err != nil {
+		return PiFleetMemberModel{}, fmt.Errorf("invalid IPv6 address %q: %w", server.IP, err)
+	}
+
+	return PiFleetMemberModel{
+		IP:      types.StringValue(ip),
+		SSHPort: types.Int64Value(server.SSHPort),
+		Status:  types.StringValue(piFleetMemberReady),
+		Error:   types.StringNull(),
+	}, nil
+}
+
+func failedPiFleetMember(err error) PiFleetMemberModel {
+	return PiFleetMemberModel{
+		IP:      types.StringNull(),
+		SSHPort: types.Int64Null(),
+		Status:  types.StringValue(piFleetMemberFailed),
+		Error:   types.StringValue(err.Error()),
+	}
+}
+
+func piFleetMembersFromValue(ctx context.Context, value types.Map) (map[string]PiFleetMemberModel, diag.Diagnostics) {
+	members := map[string]PiFleetMemberModel{}
+	if value.IsNull() || value.IsUnknown() {
+		return members, nil
+	}
+
+	diags := value.ElementsAs(ctx, &members, false)
+
+	return members, diags
+}
+
+func piFleetMembersValue(ctx context.Context, members map[string]PiFleetMemberModel) (types.Map, diag.Diagnostics) {
+	return types.MapValueFrom(ctx, piFleetMemberType, members)
+}
+
+// planPiFleetMembers returns the members to delete, to replace and to
+// create so that the fleet has the wanted members. Members are removed when
+// the fleet shrinks, replaced when the spec changes or they failed, and
+// created when they are missing.
+func planPiFleetMembers(members map[string]PiFleetMemberModel, wanted []string, specChanged bool) (remove, replace, create []string) {
+	isWanted := make(map[string]bool, len(wanted))
+	for _, identifier := range wanted {
+		isWanted[identifier] = true
+	}
+
+	for _, identifier := range sortedKeys(members) {
+		switch {
+		case !isWanted[identifier]:
+			remove = append(remove, identifier)
+		case specChanged || members[identifier].Status.ValueString() != piFleetMemberReady:
+			replace = append(replace, identifier)
+		}
+	}
+	for _, identifier := range wanted {
+		if _, ok := members[identifier]; !ok {
+			create = append(create, identifier)
+		}
+	}
+
+	return remove, replace, create
+}
+
+// createRequest returns the request used to create each member.
+func (m PiFleetResourceModel) createRequest(ctx context.Context) (mbPi.CreateRequest, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	request := mbPi.CreateRequest{
+		Model:    m.Model.ValueInt64(),
+		Memory:   m.Memory.ValueInt64(),
+		CPUSpeed: m.CPUSpeed.ValueInt64(),
+		DiskSize: m.DiskSize.ValueInt64(),
+		OSImage:  m.OSImage.ValueString(),
+	}
+
+	if !m.SSHKeys.IsNull() && !m.SSHKeys.IsUnknown() {
+		var lines []string
+		diags.Append(m.SSHKeys.ElementsAs(ctx, &lines, false)...)
+		if diags.HasError() {
+			return request, diags
+		}
+
+		payload, _, err := authorizedKeys(lines)
+		if err != nil {
+			diags.AddAttributeError(path.Root("ssh_keys"), "Invalid SSH key", err.Error())
+			return request, diags
+		}
+		request.SSHKey = payload
+	}
+
+	return request, diags
+}
+
+// specChanged reports whether the members must be replaced to match the plan.
+func (m PiFleetResourceModel) specChanged(state PiFleetResourceModel) bool {
+	return !m.Model.Equal(state.Model) ||
+		!m.Memory.Equal(state.Memory) ||
+		!m.CPUSpeed.Equal(state.CPUSpeed) ||
+		!m.DiskSize.Equal(state.DiskSize) ||
+		!m.OSImage.Equal(state.OSImage)
+}
+
+// createMembers creates the members and waits for them to be provisioned,
+// recording each one as ready or failed.
+func (r *PiFleetResource) createMembers(ctx context.Context, identifiers []string, request mbPi.CreateRequest, concurrency int, members map[string]PiFleetMemberModel) {
+	var mu sync.Mutex
+
+	errs := forEachConcurrently(ctx, identifiers, concurrency, func(ctx context.Context, identifier string) error {
+		tflog.Info(ctx, fmt.Sprintf("creating Pi fleet member %s", identifier))
+
+		if err := r.client.Pi().StartCreate(ctx, identifier, request); err != nil {
+			return err
+		}
+
+		if err := waitForPiProvisioning(ctx, r.client, identifier, piWaitPollInterval); err != nil {
+			return err
+		}
+
+		server, err := r.client.Pi().Get(ctx, identifier)
+		if err != nil {
+			return err
+		}
+
+		member, err := readyPiFleetMember(server)
+		if err != nil {
+			return err
+		}
+
+		mu.Lock()
+		members[identifier] = member
+		mu.Unlock()
+
+		return nil
+	})
+
+	for identifier, err := range errs {
+		members[identifier] = failedPiFleetMember(err)
+	}
+}
+
+// deleteMembers deletes the members, removing each one that was deleted
+// or no longer exists from members, and returns the errors for those that
+// were not. A failed member may still exist, as it can fail after it was
+// created, so it is deleted like any other.
+func (r *PiFleetResource) deleteMembers(ctx context.Context, identifiers []string, concurrency int, members map[string]PiFleetMemberModel) map[string]error {
+	errs := forEachConcurrently(ctx, identifiers, concurrency, func(ctx context.Context, identifier string) error {
+		tflog.Info(ctx, fmt.Sprintf("deleting Pi fleet member %s", identifier))
+
+		err := r.client.Pi().Delete(ctx, identifier)
+		if err != nil && piNotFound(err) {
+			tflog.Info(ctx, fmt.Sprintf("Pi fleet member %s was already deleted", identifier))
+			return nil
+		}
+
+		return err
+	})
+
+	for _, identifier := range identifiers {
+		if _, ok := errs[identifier]; !ok {
+			delete(members, identifier)
+		}
+	}
+
+	return errs
+}
+
+// warnFailedMembers adds a warning listing the members that failed.
+func warnFailedMembers(members map[string]PiFleetMemberModel, diags *diag.Diagnostics) {
+	var failed []string
+	for _, identifier := range sortedKeys(members) {
+		if members[identifier].Status.ValueString() == piFleetMemberFailed {
+			failed = append(failed, fmt.Sprintf("%s: %s", identifier, members[identifier].Error.ValueString()))
+		}
+	}
+
+	if len(failed) == 0 {
+		return
+	}
+
+	diags.AddWarning(
+		"Some Pi fleet members failed",
+		fmt.Sprintf("%d of %d members could not be created and will be retried on the next apply:\n\n%s",
+			len(failed), len(members), strings.Join(failed, "\n")),
+	)
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *PiFleetResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan PiFleetResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	request, diags := plan.createRequest(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultPiCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	identifiers := piFleetMemberIdentifiers(plan.IdentifierPrefix.ValueString(), plan.Size.ValueInt64())
+	members := map[string]PiFleetMemberModel{}
+
+	r.createMembers(ctx, identifiers, request, int(plan.Concurrency.ValueInt64()), members)
+
+	state := plan
+	state.ID = plan.IdentifierPrefix
+
+	state.Members, diags = piFleetMembersValue(ctx, members)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	warnFailedMembers(members, &resp.Diagnostics)
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *PiFleetResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state PiFleetResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	members, diags := piFleetMembersFromValue(ctx, state.Members)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for _, identifier := range sortedKeys(members) {
+		if members[identifier].Status.ValueString() != piFleetMemberReady {
+			continue
+		}
+
+		server, err := r.client.Pi().Get(ctx, identifier)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error reading Mythic Beasts Pi fleet member",
+				"Could not read Pi "+identifier+": "+err.Error(),
+			)
+			return
+		}
+
+		member, err := readyPiFleetMember(server)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error reading Mythic Beasts Pi fleet member",
+				fmt.Sprintf("Could not read Pi %s: %s", identifier, err.Error()),
+			)
+			return
+		}
+		members[identifier] = member
+	}
+
+	state.Members, diags = piFleetMembersValue(ctx, members)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// ModifyPlan marks the members as unknown when they will change.
+func (r *PiFleetResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to do on create or destroy.
+	if req.Plan.Raw.IsNull() || req.State.Raw.IsNull() {
+		return
+	}
+
+	var plan PiFleetResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var state PiFleetResourceModel
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	members, diags := piFleetMembersFromValue(ctx, state.Members)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	wanted := piFleetMemberIdentifiers(plan.IdentifierPrefix.ValueString(), plan.Size.ValueInt64())
+	remove, replace, create := planPiFleetMembers(members, wanted, plan.specChanged(state))
+
+	if len(remove) > 0 || len(replace) > 0 || len(create) > 0 {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("members"), types.MapUnknown(piFleetMemberType))...)
+	}
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *PiFleetResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan PiFleetResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var state PiFleetResourceModel
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	members, diags := piFleetMembersFromValue(ctx, state.Members)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	request, diags := plan.createRequest(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultPiCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	concurrency := int(plan.Concurrency.ValueInt64())
+	wanted := piFleetMemberIdentifiers(plan.IdentifierPrefix.ValueString(), plan.Size.ValueInt64())
+	remove, replace, create := planPiFleetMembers(members, wanted, plan.specChanged(state))
+
+	errs := r.deleteMembers(ctx, remove, concurrency, members)
+	for _, identifier := range sortedKeys(errs) {
+		resp.Diagnostics.AddError(
+			"Error deleting Pi fleet member",
+			fmt.Sprintf("Could not delete Pi %s: %s", identifier, errs[identifier].Error()),
+		)
+	}
+
+	applied := !resp.Diagnostics.HasError()
+	if applied {
+		r.createMembers(ctx, create, request, concurrency, members)
+		applied = r.replaceMembers(ctx, replace, request, concurrency, members, &resp.Diagnostics)
+	}
+
+	// When the change stopped part way, the members that should exist are
+	// recorded as failed and the prior spec and keys are kept, for the next
+	// apply to finish the change.
+	if !applied {
+		for _, identifier := range wanted {
+			if _, ok := members[identifier]; !ok {
+				members[identifier] = failedPiFleetMember(errors.New("not created because changing other members of the fleet failed"))
+			}
+		}
+	}
+
+	keysUpdated := applied
+	if applied && !plan.SSHKeys.Equal(state.SSHKeys) && request.SSHKey != "" {
+		keysUpdated = r.updateSSHKeys(ctx, members, slices.Concat(create, replace), request.SSHKey, concurrency, &resp.Diagnostics)
+	}
+
+	prior := state
+	state = plan
+	state.ID = plan.IdentifierPrefix
+	if !applied {
+		state.Model = prior.Model
+		state.Memory = prior.Memory
+		state.CPUSpeed = prior.CPUSpeed
+		state.DiskSize = prior.DiskSize
+		state.OSImage = prior.OSImage
+	}
+	if !keysUpdated {
+		state.SSHKeys = prior.SSHKeys
+	}
+	state.Members, diags = piFleetMembersValue(ctx, members)
+	resp.Diagnostics.Append(diags...)
+
+	warnFailedMembers(members, &resp.Diagnostics)
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// replaceMembers deletes and recreates the members one batch of
+// concurrency members at a time, so the rest of the fleet keeps running.
+// It stops when a batch could not be deleted, or could not be recreated
+// before the last batch, and reports whether every batch was replaced.
+func (r *PiFleetResource) replaceMembers(ctx context.Context, identifiers []string, request mbPi.CreateRequest, concurrency int, members map[string]PiFleetMemberModel, diags *diag.Diagnostics) bool {
+	replaced := 0
+	for batch := range slices.Chunk(identifiers, concurrency) {
+		errs := r.deleteMembers(ctx, batch, concurrency, members)
+		for _, identifier := range sortedKeys(errs) {
+			diags.AddError(
+				"Error deleting Pi fleet member",
+				fmt.Sprintf("Could not delete Pi %s to replace it: %s", identifier, errs[identifier].Error()),
+			)
+		}
+		if len(errs) > 0 {
+			return false
+		}
+
+		r.createMembers(ctx, batch, request, concurrency, members)
+		replaced += len(batch)
+
+		for _, identifier := range batch {
+			if members[identifier].Status.ValueString() == piFleetMemberReady || replaced == len(identifiers) {
+				continue
+			}
+
+			diags.AddWarning(
+				"Stopped replacing Pi fleet members",
+				fmt.Sprintf("%d of %d members were not replaced because members of an earlier batch could not be created. They keep running and the change will be retried on the next apply.",
+					len(identifiers)-replaced, len(identifiers)),
+			)
+
+			return false
+		}
+	}
+
+	return true
+}
+
+// updateSSHKeys installs the keys on the ready members that were not just
+// created with them, and reports whether every member was updated.
+func (r *PiFleetResource) updateSSHKeys(ctx context.Context, members map[string]PiFleetMemberModel, created []string, sshKeys string, concurrency int, diags *diag.Diagnostics) bool {
+	skip := make(map[string]bool, len(created))
+	for _, identifier := range created {
+		skip[identifier] = true
+	}
+
+	var identifiers []string
+	for _, identifier := range sortedKeys(members) {
+		if !skip[identifier] && members[identifier].Status.ValueString() == piFleetMemberReady {
+			identifiers = append(identifiers, identifier)
+		}
+	}
+
+	errs := forEachConcurrently(ctx, identifiers, concurrency, func(ctx context.Context, identifier string) error {
+		_, err := r.client.Pi().UpdateSSHKey(ctx, identifier, mbPi.UpdateSSHKeyRequest{SSHKey: sshKeys})
+		return err
+	})
+
+	for _, identifier := range sortedKeys(errs) {
+		diags.AddError(
+			"Error updating Pi SSH key",
+			fmt.Sprintf("Could not update the SSH keys of Pi %s: %s", identifier, errs[identifier].Error()),
+		)
+	}
+
+	return len(errs) == 0
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *PiFleetResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state PiFleetResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	members, diags := piFleetMembersFromValue(ctx, state.Members)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultPiDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	errs := r.deleteMembers(ctx, sortedKeys(members), int(state.Concurrency.ValueInt64()), members)
+	if len(errs) == 0 {
+		return
+	}
+
+	for _, identifier := range sortedKeys(errs) {
+		resp.Diagnostics.AddError(
+			"Error deleting Pi fleet member",
+			fmt.Sprintf("Could not delete Pi %s: %s", identifier, errs[identifier].Error()),
+		)
+	}
+
+	// Keep the members that still exist, so the next destroy retries them.
+	state.Members, diags = piFleetMembersValue(ctx, members)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
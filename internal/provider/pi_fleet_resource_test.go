@@ -0,0 +1,104 @@
+// Copyright IBM Corp. 2021, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+func TestAccPiFleetResource(t *testing.T) {
+	prefix := testAccIdentifier("tfpf", 16)
+	resourceAddress := "mythicbeasts_pi_fleet.test"
+
+	readyMember := knownvalue.ObjectExact(map[string]knownvalue.Check{
+		"ip":       knownvalue.NotNull(),
+		"ssh_port": knownvalue.NotNull(),
+		"status":   knownvalue.StringExact("ready"),
+		"error":    knownvalue.Null(),
+	})
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccPiFleetResourceConfig(prefix, 1),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						resourceAddress,
+						tfjsonpath.New("id"),
+						knownvalue.StringExact(prefix),
+					),
+					statecheck.ExpectKnownValue(
+						resourceAddress,
+						tfjsonpath.New("members"),
+						knownvalue.MapExact(map[string]knownvalue.Check{
+							prefix + "-1": readyMember,
+						}),
+					),
+				},
+			},
+			// Grow the fleet in place
+			{
+				Config: testAccPiFleetResourceConfig(prefix, 2),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction(resourceAddress, plancheck.ResourceActionUpdate),
+					},
+				},
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						resourceAddress,
+						tfjsonpath.New("members"),
+						knownvalue.MapExact(map[string]knownvalue.Check{
+							prefix + "-1": readyMember,
+							prefix + "-2": readyMember,
+						}),
+					),
+				},
+			},
+			// Shrink the fleet, removing the highest numbered member
+			{
+				Config: testAccPiFleetResourceConfig(prefix, 1),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						resourceAddress,
+						tfjsonpath.New("members"),
+						knownvalue.MapExact(map[string]knownvalue.Check{
+							prefix + "-1": readyMember,
+						}),
+					),
+				},
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccPiFleetResourceConfig(prefix string, size int) string {
+	return fmt.Sprintf(`
+resource "mythicbeasts_pi_fleet" "test" {
+  identifier_prefix = %[1]q
+  size              = %[2]d
+  model             = 4
+  memory            = 4096
+  os_image          = "rpi-bookworm-arm64"
+  ssh_keys          = [%[3]q]
+
+  timeouts {
+    create = "20m"
+    update = "20m"
+    delete = "5m"
+  }
+}
+`, prefix, size, piSSHKey)
+}
@@ -0,0 +1,129 @@
+// Copyright IBM Corp. 2021, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	mbPi "github.com/paultibbetts/mythicbeasts-client-go/pi"
+)
+
+func TestPiFleetMemberIdentifiers(t *testing.T) {
+	tests := []struct {
+		name string
+		size int64
+		want []string
+	}{
+		{name: "empty", size: 0, want: []string{}},
+		{name: "one", size: 1, want: []string{"web-1"}},
+		{name: "several", size: 3, want: []string{"web-1", "web-2", "web-3"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := piFleetMemberIdentifiers("web", tt.size)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("piFleetMemberIdentifiers() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestForEachConcurrently(t *testing.T) {
+	identifiers := piFleetMemberIdentifiers("web", 10)
+
+	var running, peak atomic.Int64
+	errs := forEachConcurrently(context.Background(), identifiers, 3, func(_ context.Context, identifier string) error {
+		n := running.Add(1)
+		defer running.Add(-1)
+
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+
+		time.Sleep(5 * time.Millisecond)
+
+		if identifier == "web-4" || identifier == "web-7" {
+			return errors.New("no capacity")
+		}
+
+		return nil
+	})
+
+	if got := peak.Load(); got > 3 {
+		t.Fatalf("ran %d calls at once, want at most 3", got)
+	}
+
+	if len(errs) != 2 || errs["web-4"] == nil || errs["web-7"] == nil {
+		t.Fatalf("errors = %v, want errors for web-4 and web-7", errs)
+	}
+}
+
+func TestPlanPiFleetMembers(t *testing.T) {
+	ready, err := readyPiFleetMember(mbPi.Server{IP: "2a00:1098::1", SSHPort: 5022})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	failed := failedPiFleetMember(errors.New("no capacity"))
+	wanted := piFleetMemberIdentifiers("web", 3)
+
+	tests := []struct {
+		name        string
+		members     map[string]PiFleetMemberModel
+		specChanged bool
+		wantRemove  []string
+		wantReplace []string
+		wantCreate  []string
+	}{
+		{
+			name:    "unchanged",
+			members: map[string]PiFleetMemberModel{"web-1": ready, "web-2": ready, "web-3": ready},
+		},
+		{
+			name:       "missing member",
+			members:    map[string]PiFleetMemberModel{"web-1": ready, "web-3": ready},
+			wantCreate: []string{"web-2"},
+		},
+		{
+			name:        "failed member",
+			members:     map[string]PiFleetMemberModel{"web-1": ready, "web-2": failed, "web-3": ready},
+			wantReplace: []string{"web-2"},
+		},
+		{
+			name:       "shrink",
+			members:    map[string]PiFleetMemberModel{"web-1": ready, "web-2": ready, "web-3": ready, "web-4": ready},
+			wantRemove: []string{"web-4"},
+		},
+		{
+			name:        "spec changed",
+			members:     map[string]PiFleetMemberModel{"web-1": ready, "web-2": ready},
+			specChanged: true,
+			wantReplace: []string{"web-1", "web-2"},
+			wantCreate:  []string{"web-3"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			remove, replace, create := planPiFleetMembers(tt.members, wanted, tt.specChanged)
+			if !reflect.DeepEqual(remove, tt.wantRemove) {
+				t.Fatalf("remove = %v, want %v", remove, tt.wantRemove)
+			}
+			if !reflect.DeepEqual(replace, tt.wantReplace) {
+				t.Fatalf("replace = %v, want %v", replace, tt.wantReplace)
+			}
+			if !reflect.DeepEqual(create, tt.wantCreate) {
+				t.Fatalf("create = %v, want %v", create, tt.wantCreate)
+			}
+		})
+	}
+}
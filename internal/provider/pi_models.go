@@ -0,0 +1,178 @@
+// Copyright IBM Corp. 2021, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/paultibbetts/mythicbeasts-client-go"
+	mbPi "github.com/paultibbetts/mythicbeasts-client-go/pi"
+)
+
+// piModelList caches the Pi models reported by the API so that they are
+// fetched once per run rather than once per resource.
+type piModelList struct {
+	mu     sync.Mutex
+	models []mbPi.Model
+}
+
+// piModelLists holds one piModelList per configured client.
+var piModelLists sync.Map
+
+// listPiModels returns the Pi models reported by the API, fetching them on
+// first use. A failed request is not cached so that it can be retried.
+func listPiModels(ctx context.Context, client *mythicbeasts.Client) ([]mbPi.Model, error) {
+	list, _ := piModelLists.LoadOrStore(client, &piModelList{})
+	cache := list.(*piModelList)
+
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	if cache.models != nil {
+		return cache.models, nil
+	}
+
+	models, err := client.Pi().ListModels(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	cache.models = models
+
+	return models, nil
+}
+
+// piModelNumbers returns the distinct model numbers, in order.
+func piModelNumbers(models []mbPi.Model) []int64 {
+	var numbers []int64
+
+	for _, m := range models {
+		if !slices.Contains(numbers, m.Model) {
+			numbers = append(numbers, m.Model)
+		}
+	}
+
+	slices.Sort(numbers)
+
+	return numbers
+}
+
+// checkPiModel returns the diagnostic for a model number the API does not
+// report, if any.
+func checkPiModel(models []mbPi.Model, model int64) (string, bool) {
+	numbers := piModelNumbers(models)
+	if slices.Contains(numbers, model) {
+		return "", true
+	}
+
+	return fmt.Sprintf("Raspberry Pi model %d is not available. Available models: %s.", model, joinInt64s(numbers)), false
+}
+
+// selectPiModel returns the lowest spec, by memory then CPU speed, that
+// matches the model and any memory and CPU speed that were configured.
+func selectPiModel(models []mbPi.Model, model int64, memory types.Int64, cpuSpeed types.Int64) (mbPi.Model, bool) {
+	var matches []mbPi.Model
+
+	for _, m := range models {
+		if m.Model != model {
+			continue
+		}
+
+		if !memory.IsNull() && !memory.IsUnknown() && m.Memory != memory.ValueInt64() {
+			continue
+		}
+
+		if !cpuSpeed.IsNull() && !cpuSpeed.IsUnknown() && m.CPUSpeed != cpuSpeed.ValueInt64() {
+			continue
+		}
+
+		matches = append(matches, m)
+	}
+
+	if len(matches) == 0 {
+		return mbPi.Model{}, false
+	}
+
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].Memory != matches[j].Memory {
+			return matches[i].Memory < matches[j].Memory
+		}
+		if matches[i].CPUSpeed != matches[j].CPUSpeed {
+			return matches[i].CPUSpeed < matches[j].CPUSpeed
+		}
+		return matches[i].NICSpeed < matches[j].NICSpeed
+	})
+
+	return matches[0], true
+}
+
+// describePiModels lists the specs available for a model, for use in
+// error messages.
+func describePiModels(models []mbPi.Model, model int64) string {
+	var specs []string
+
+	for _, m := range models {
+		if m.Model != model {
+			continue
+		}
+
+		specs = append(specs, fmt.Sprintf("memory = %d, cpu_speed = %d", m.Memory, m.CPUSpeed))
+	}
+
+	if len(specs) == 0 {
+		return "none"
+	}
+
+	sort.Strings(specs)
+
+	return strings.Join(specs, "; ")
+}
+
+// modifyPlanModel rejects combinations of model, memory and cpu_speed that
+// are not available, and plans the memory, cpu_speed and nic_speed the Pi
+// will be given.
+func (r *PiResource) modifyPlanModel(ctx context.Context, config PiResourceModel, plan PiResourceModel, resp *resource.ModifyPlanResponse) {
+	if plan.Model.IsUnknown() || config.Memory.IsUnknown() || config.CPUSpeed.IsUnknown() {
+		return
+	}
+
+	models, err := listPiModels(ctx, r.client)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to read Mythic Beasts Pi models",
+			err.Error(),
+		)
+		return
+	}
+
+	model := plan.Model.ValueInt64()
+
+	if detail, ok := checkPiModel(models, model); !ok {
+		resp.Diagnostics.AddAttributeError(path.Root("model"), "Unknown Pi model", detail)
+		return
+	}
+
+	selected, ok := selectPiModel(models, model, config.Memory, config.CPUSpeed)
+	if !ok {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("model"),
+			"Unavailable Pi specification",
+			fmt.Sprintf("No Raspberry Pi %d is available with the configured memory and cpu_speed. Available specifications: %s.",
+				model, describePiModels(models, model)),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("memory"), types.Int64Value(selected.Memory))...)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("cpu_speed"), types.Int64Value(selected.CPUSpeed))...)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("nic_speed"), types.Int64Value(selected.NICSpeed))...)
+}
@@ -105,6 +105,30 @@ func ExpectAllModelsEqual(addr string, want int64) statecheck.StateCheck {
 	return expectAllModelsEqual{resourceAddress: addr, want: want}
 }
 
+func TestAccPiModelsDataSourceByModel(t *testing.T) {
+	for _, model := range testAccPiModelNumbers(t) {
+		t.Run(fmt.Sprintf("model_%d", model), func(t *testing.T) {
+			resource.Test(t, resource.TestCase{
+				PreCheck:                 func() { testAccPreCheck(t) },
+				ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+				Steps: []resource.TestStep{
+					// Read testing
+					{
+						Config: fmt.Sprintf(`
+data "mythicbeasts_pi_models" "test" {
+  model = %d
+}
+`, model),
+						ConfigStateChecks: []statecheck.StateCheck{
+							ExpectAllModelsEqual("data.mythicbeasts_pi_models.test", model),
+						},
+					},
+				},
+			})
+		})
+	}
+}
+
 func TestAccPiModelsDataSource(t *testing.T) {
 	resource.Test(t, resource.TestCase{
 		PreCheck:                 func() { testAccPreCheck(t) },
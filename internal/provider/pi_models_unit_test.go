@@ -0,0 +1,63 @@
+// Copyright IBM Corp. 2021, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	mbPi "github.com/paultibbetts/mythicbeasts-client-go/pi"
+)
+
+func TestSelectPiModel(t *testing.T) {
+	models := []mbPi.Model{
+		{Model: 4, Memory: 8192, CPUSpeed: 1500, NICSpeed: 1000},
+		{Model: 4, Memory: 4096, CPUSpeed: 2000, NICSpeed: 1000},
+		{Model: 4, Memory: 4096, CPUSpeed: 1500, NICSpeed: 1000},
+		{Model: 3, Memory: 1024, CPUSpeed: 1200, NICSpeed: 100},
+	}
+
+	got, ok := selectPiModel(models, 4, types.Int64Null(), types.Int64Null())
+	if !ok || got.Memory != 4096 || got.CPUSpeed != 1500 {
+		t.Fatalf("expected the lowest model 4 spec, got %+v", got)
+	}
+
+	got, ok = selectPiModel(models, 4, types.Int64Null(), types.Int64Value(2000))
+	if !ok || got.Memory != 4096 || got.CPUSpeed != 2000 {
+		t.Fatalf("expected the 2000 MHz spec, got %+v", got)
+	}
+
+	got, ok = selectPiModel(models, 4, types.Int64Value(8192), types.Int64Null())
+	if !ok || got.Memory != 8192 {
+		t.Fatalf("expected the 8192 MB spec, got %+v", got)
+	}
+
+	if _, ok := selectPiModel(models, 3, types.Int64Value(4096), types.Int64Null()); ok {
+		t.Fatal("expected no match for a model 3 with 4096 MB")
+	}
+}
+
+func TestCheckPiModel(t *testing.T) {
+	models := []mbPi.Model{
+		{Model: 5, Memory: 8192},
+		{Model: 4, Memory: 4096},
+		{Model: 4, Memory: 8192},
+	}
+
+	if got := piModelNumbers(models); len(got) != 2 || got[0] != 4 || got[1] != 5 {
+		t.Fatalf("expected models [4 5], got %v", got)
+	}
+
+	if _, ok := checkPiModel(models, 5); !ok {
+		t.Fatal("expected model 5 to be available")
+	}
+
+	detail, ok := checkPiModel(models, 3)
+	if ok {
+		t.Fatal("expected model 3 to be unavailable")
+	}
+	if detail != "Raspberry Pi model 3 is not available. Available models: 4, 5." {
+		t.Fatalf("unexpected detail %q", detail)
+	}
+}
@@ -93,6 +93,20 @@ func (d *piOperatingSystemsDataSource) Read(ctx context.Context, req datasource.
 	}
 	state.Model = model
 
+	models, err := listPiModels(ctx, d.client)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to read Mythic Beasts Pi models",
+			err.Error(),
+		)
+		return
+	}
+
+	if detail, ok := checkPiModel(models, model.ValueInt64()); !ok {
+		resp.Diagnostics.AddAttributeError(path.Root("model"), "Unknown Pi model", detail)
+		return
+	}
+
 	piOperatingSystems, err := d.client.Pi().GetOperatingSystems(ctx, model.ValueInt64())
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -4,6 +4,7 @@
 package provider
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
@@ -36,6 +37,43 @@ func TestAccPiOperatingSystemsDataSource(t *testing.T) {
 	})
 }
 
+func TestAccPiOperatingSystemsDataSourceByModel(t *testing.T) {
+	for _, model := range testAccPiModelNumbers(t) {
+		t.Run(fmt.Sprintf("model_%d", model), func(t *testing.T) {
+			resource.Test(t, resource.TestCase{
+				PreCheck:                 func() { testAccPreCheck(t) },
+				ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+				Steps: []resource.TestStep{
+					// Read testing
+					{
+						Config: testAccPiOperatingSystemsDataSourceModelConfig(model),
+						ConfigStateChecks: []statecheck.StateCheck{
+							statecheck.ExpectKnownValue(
+								"data.mythicbeasts_pi_operating_systems.test",
+								tfjsonpath.New("model"),
+								knownvalue.Int64Exact(model),
+							),
+							statecheck.ExpectKnownValue(
+								"data.mythicbeasts_pi_operating_systems.test",
+								tfjsonpath.New("images"),
+								knownvalue.NotNull(),
+							),
+						},
+					},
+				},
+			})
+		})
+	}
+}
+
+func testAccPiOperatingSystemsDataSourceModelConfig(model int64) string {
+	return fmt.Sprintf(`
+data "mythicbeasts_pi_operating_systems" "test" {
+  model = %d
+}
+`, model)
+}
+
 const testAccPiOperatingSystemsDataSourceConfig = `
 data "mythicbeasts_pi_operating_systems" "test" {
   model = 3
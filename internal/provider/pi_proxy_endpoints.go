@@ -0,0 +1,344 @@
+// Copyright IBM Corp. 2021, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/paultibbetts/mythicbeasts-client-go"
+	mbProxy "github.com/paultibbetts/mythicbeasts-client-go/proxy"
+)
+
+// PiProxyEndpointModel maps an entry of the proxy_endpoints attribute of the Pi resource.
+type PiProxyEndpointModel struct {
+	Domain        types.String `tfsdk:"domain"`
+	Hostname      types.String `tfsdk:"hostname"`
+	Site          types.String `tfsdk:"site"`
+	ProxyProtocol types.Bool   `tfsdk:"proxy_protocol"`
+}
+
+var piProxyEndpointAttrTypes = map[string]attr.Type{
+	"domain":         types.StringType,
+	"hostname":       types.StringType,
+	"site":           types.StringType,
+	"proxy_protocol": types.BoolType,
+}
+
+var piProxyEndpointType = types.ObjectType{AttrTypes: piProxyEndpointAttrTypes}
+
+type piProxyEndpoint struct {
+	Domain        string
+	Hostname      string
+	Site          string
+	ProxyProtocol bool
+}
+
+// key identifies the endpoint in the proxy API, which keys endpoints by
+// domain, hostname, address and site.
+func (e piProxyEndpoint) key() string {
+	return e.Domain + "/" + e.Hostname + "/" + e.Site
+}
+
+func piProxyEndpointsSchema() schema.SetNestedAttribute {
+	return schema.SetNestedAttribute{
+		Optional: true,
+		MarkdownDescription: "IPv4 to IPv6 proxy endpoints that forward to the Pi's `ip`. " +
+			"The endpoints are created, updated and deleted along with the Pi, and follow its address if it changes.\n\n" +
+			"The domain must be registered with Mythic Beasts, as for the [`mythicbeasts_proxy_endpoint` resource](../resources/proxy_endpoint).",
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: map[string]schema.Attribute{
+				"domain": schema.StringAttribute{
+					Required:            true,
+					MarkdownDescription: "Domain part of the hostname to be proxied (e.g. \"example.com\").",
+					Validators: []validator.String{
+						stringvalidator.RegexMatches(
+							regexp.MustCompile(`^\S+$`),
+							"must not be empty or contain whitespace",
+						),
+					},
+				},
+				"hostname": schema.StringAttribute{
+					Required:            true,
+					MarkdownDescription: "Host part of the hostname to be proxied (e.g. \"www\" or \"@\").",
+					Validators: []validator.String{
+						stringvalidator.RegexMatches(
+							regexp.MustCompile(`^\S+$`),
+							"must not be empty or contain whitespace",
+						),
+					},
+				},
+				"site": schema.StringAttribute{
+					Optional:            true,
+					Computed:            true,
+					Default:             stringdefault.StaticString("all"),
+					MarkdownDescription: "Site in which the proxy server is located, or `all` for all sites.\nDefault: `all`",
+					Validators: []validator.String{
+						stringvalidator.RegexMatches(
+							regexp.MustCompile(`^\S+$`),
+							"must not be empty or contain whitespace",
+						),
+					},
+				},
+				"proxy_protocol": schema.BoolAttribute{
+					Optional:            true,
+					Computed:            true,
+					Default:             booldefault.StaticBool(false),
+					MarkdownDescription: "Whether PROXY protocol is enabled for this endpoint.\nDefault: `false`",
+				},
+			},
+		},
+	}
+}
+
+func piProxyEndpointsFromValue(ctx context.Context, value types.Set) ([]piProxyEndpoint, diag.Diagnostics) {
+	if value.IsNull() || value.IsUnknown() {
+		return nil, nil
+	}
+
+	var models []PiProxyEndpointModel
+	diags := value.ElementsAs(ctx, &models, false)
+
+	endpoints := make([]piProxyEndpoint, 0, len(models))
+	for _, model := range models {
+		endpoints = append(endpoints, piProxyEndpoint{
+			Domain:        model.Domain.ValueString(),
+			Hostname:      model.Hostname.ValueString(),
+			Site:          model.Site.ValueString(),
+			ProxyProtocol: model.ProxyProtocol.ValueBool(),
+		})
+	}
+
+	return endpoints, diags
+}
+
+func piProxyEndpointsValue(endpoints []piProxyEndpoint) (types.Set, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	values := make([]attr.Value, 0, len(endpoints))
+	for _, endpoint := range endpoints {
+		value, d := types.ObjectValue(piProxyEndpointAttrTypes, map[string]attr.Value{
+			"domain":         types.StringValue(endpoint.Domain),
+			"hostname":       types.StringValue(endpoint.Hostname),
+			"site":           types.StringValue(endpoint.Site),
+			"proxy_protocol": types.BoolValue(endpoint.ProxyProtocol),
+		})
+		diags = append(diags, d...)
+		values = append(values, value)
+	}
+
+	set, d := types.SetValue(piProxyEndpointType, values)
+	diags = append(diags, d...)
+
+	return set, diags
+}
+
+// diffPiProxyEndpoints returns the endpoints to delete from the old address
+// and to create or update at the new address. Every endpoint is moved when
+// the address changes.
+func diffPiProxyEndpoints(oldAddress string, current []piProxyEndpoint, newAddress string, desired []piProxyEndpoint) (remove []piProxyEndpoint, apply []piProxyEndpoint) {
+	moved := oldAddress != newAddress
+
+	wanted := make(map[string]piProxyEndpoint, len(desired))
+	for _, endpoint := range desired {
+		wanted[endpoint.key()] = endpoint
+	}
+
+	have := make(map[string]piProxyEndpoint, len(current))
+	for _, endpoint := range current {
+		have[endpoint.key()] = endpoint
+
+		if _, ok := wanted[endpoint.key()]; moved || !ok {
+			remove = append(remove, endpoint)
+		}
+	}
+
+	for _, endpoint := range desired {
+		if existing, ok := have[endpoint.key()]; moved || !ok || existing != endpoint {
+			apply = append(apply, endpoint)
+		}
+	}
+
+	sortPiProxyEndpoints(remove)
+	sortPiProxyEndpoints(apply)
+
+	return remove, apply
+}
+
+func sortPiProxyEndpoints(endpoints []piProxyEndpoint) {
+	sort.Slice(endpoints, func(i, j int) bool {
+		return endpoints[i].key() < endpoints[j].key()
+	})
+}
+
+// reconcilePiProxyEndpoints points the desired endpoints at the new
+// address before removing the ones that are no longer wanted, so that
+// traffic keeps flowing while a Pi's address changes.
+func reconcilePiProxyEndpoints(ctx context.Context, api proxyEndpointAPI, oldAddress string, current []piProxyEndpoint, newAddress string, desired []piProxyEndpoint) error {
+	remove, apply := diffPiProxyEndpoints(oldAddress, current, newAddress, desired)
+
+	for _, endpoint := range apply {
+		if err := putPiProxyEndpoint(ctx, api, newAddress, endpoint); err != nil {
+			return err
+		}
+	}
+
+	if err := deletePiProxyEndpoints(ctx, api, oldAddress, remove); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// createPiProxyEndpoints creates the endpoints pointing at the address. If
+// one can't be created, the ones already created are removed again so that
+// none are left untracked.
+func createPiProxyEndpoints(ctx context.Context, api proxyEndpointAPI, address string, endpoints []piProxyEndpoint) error {
+	for i, endpoint := range endpoints {
+		err := putPiProxyEndpoint(ctx, api, address, endpoint)
+		if err == nil {
+			continue
+		}
+
+		if created := endpoints[:i]; len(created) > 0 {
+			tflog.Warn(ctx, fmt.Sprintf("removing the proxy endpoints created for %s: %s", address, err.Error()))
+
+			if rollBackErr := deletePiProxyEndpoints(ctx, api, address, created); rollBackErr != nil {
+				return errors.Join(err, fmt.Errorf("removing the endpoints already created: %w", rollBackErr))
+			}
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+// putPiProxyEndpoint creates or updates the endpoint pointing at the address.
+func putPiProxyEndpoint(ctx context.Context, api proxyEndpointAPI, address string, endpoint piProxyEndpoint) error {
+	tflog.Info(ctx, fmt.Sprintf("pointing proxy endpoint %s.%s at %s", endpoint.Hostname, endpoint.Domain, address))
+
+	_, err := api.CreateOrUpdateEndpoints(
+		ctx,
+		endpoint.Domain,
+		endpoint.Hostname,
+		address,
+		endpoint.Site,
+		[]mbProxy.EndpointRequest{{Site: endpoint.Site, ProxyProtocol: endpoint.ProxyProtocol}},
+	)
+	if err != nil {
+		return fmt.Errorf("creating proxy endpoint %s: %w", endpoint.key(), err)
+	}
+
+	return nil
+}
+
+// deletePiProxyEndpoints removes the endpoints pointing at the address.
+func deletePiProxyEndpoints(ctx context.Context, api proxyEndpointAPI, address string, endpoints []piProxyEndpoint) error {
+	for _, endpoint := range endpoints {
+		tflog.Info(ctx, fmt.Sprintf("removing proxy endpoint %s.%s from %s", endpoint.Hostname, endpoint.Domain, address))
+
+		if err := api.DeleteEndpoints(ctx, endpoint.Domain, endpoint.Hostname, address, endpoint.Site); err != nil {
+			return fmt.Errorf("deleting proxy endpoint %s: %w", endpoint.key(), err)
+		}
+	}
+
+	return nil
+}
+
+// proxyEndpointsAt returns the endpoints whose address is the given
+// normalized IPv6 address.
+func proxyEndpointsAt(endpoints []mbProxy.Endpoint, address string) []piProxyEndpoint {
+	var matched []piProxyEndpoint
+	for _, endpoint := range endpoints {
+		normalized, err := normalizeIPv6(endpoint.Address.String())
+		if err != nil || normalized != address {
+			continue
+		}
+
+		matched = append(matched, piProxyEndpoint{
+			Domain:        endpoint.Domain,
+			Hostname:      endpoint.Hostname,
+			Site:          endpoint.Site,
+			ProxyProtocol: endpoint.ProxyProtocol,
+		})
+	}
+	sortPiProxyEndpoints(matched)
+
+	return matched
+}
+
+// deleteProxyEndpointsAt removes every proxy endpoint pointing at the
+// address, including those not managed by Terraform, and returns the
+// endpoints it removed.
+func deleteProxyEndpointsAt(ctx context.Context, client *mythicbeasts.Client, address string) ([]piProxyEndpoint, error) {
+	all, err := client.Proxy().ListEndpoints(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("listing proxy endpoints: %w", err)
+	}
+
+	endpoints := proxyEndpointsAt(all, address)
+	for i, endpoint := range endpoints {
+		tflog.Info(ctx, fmt.Sprintf("removing proxy endpoint %s.%s from %s", endpoint.Hostname, endpoint.Domain, address))
+
+		if err := client.Proxy().DeleteEndpoints(ctx, endpoint.Domain, endpoint.Hostname, address, endpoint.Site); err != nil {
+			return endpoints[:i], fmt.Errorf("deleting proxy endpoint %s: %w", endpoint.key(), err)
+		}
+	}
+
+	return endpoints, nil
+}
+
+// readPiProxyEndpoints returns the endpoints that still exist at the
+// address, with their current settings.
+func readPiProxyEndpoints(ctx context.Context, client *mythicbeasts.Client, address string, endpoints []piProxyEndpoint) ([]piProxyEndpoint, error) {
+	found := make([]piProxyEndpoint, 0, len(endpoints))
+
+	for _, endpoint := range endpoints {
+		remote, ok, err := client.Proxy().GetEndpoint(ctx, endpoint.Domain, endpoint.Hostname, address, endpoint.Site)
+		if err != nil {
+			return nil, fmt.Errorf("reading proxy endpoint %s: %w", endpoint.key(), err)
+		}
+		if !ok {
+			continue
+		}
+
+		endpoint.ProxyProtocol = remote.ProxyProtocol
+		found = append(found, endpoint)
+	}
+
+	return found, nil
+}
+
+// modifyPlanProxyEndpoints plans the address the inline proxy endpoints
+// will point at, which moves them when the Pi's address has changed.
+func (r *PiResource) modifyPlanProxyEndpoints(ctx context.Context, plan PiResourceModel, state *PiResourceModel, resp *resource.ModifyPlanResponse) {
+	address := path.Root("proxy_endpoint_address")
+
+	if plan.ProxyEndpoints.IsNull() || (!plan.ProxyEndpoints.IsUnknown() && len(plan.ProxyEndpoints.Elements()) == 0) {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, address, types.StringNull())...)
+		return
+	}
+
+	if state == nil || state.IP.IsNull() {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, address, types.StringUnknown())...)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, address, state.IP)...)
+}
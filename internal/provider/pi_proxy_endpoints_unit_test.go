@@ -0,0 +1,102 @@
+// Copyright IBM Corp. 2021, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"net/netip"
+	"reflect"
+	"strings"
+	"testing"
+
+	mbProxy "github.com/paultibbetts/mythicbeasts-client-go/proxy"
+)
+
+func TestDiffPiProxyEndpoints(t *testing.T) {
+	www := piProxyEndpoint{Domain: "example.com", Hostname: "www", Site: "all"}
+	api := piProxyEndpoint{Domain: "example.com", Hostname: "api", Site: "all"}
+	apiProxied := piProxyEndpoint{Domain: "example.com", Hostname: "api", Site: "all", ProxyProtocol: true}
+
+	remove, apply := diffPiProxyEndpoints("2a00:1098::1", []piProxyEndpoint{www, api}, "2a00:1098::1", []piProxyEndpoint{www, api})
+	if len(remove) != 0 || len(apply) != 0 {
+		t.Fatalf("expected no changes, got remove %v apply %v", remove, apply)
+	}
+
+	remove, apply = diffPiProxyEndpoints("2a00:1098::1", []piProxyEndpoint{www, api}, "2a00:1098::1", []piProxyEndpoint{apiProxied})
+	if !reflect.DeepEqual(remove, []piProxyEndpoint{www}) {
+		t.Fatalf("expected www to be removed, got %v", remove)
+	}
+	if !reflect.DeepEqual(apply, []piProxyEndpoint{apiProxied}) {
+		t.Fatalf("expected api to be updated, got %v", apply)
+	}
+
+	remove, apply = diffPiProxyEndpoints("2a00:1098::1", []piProxyEndpoint{www}, "2a00:1098::2", []piProxyEndpoint{www})
+	if !reflect.DeepEqual(remove, []piProxyEndpoint{www}) || !reflect.DeepEqual(apply, []piProxyEndpoint{www}) {
+		t.Fatalf("expected www to move to the new address, got remove %v apply %v", remove, apply)
+	}
+}
+
+func TestProxyEndpointsAt(t *testing.T) {
+	endpoints := []mbProxy.Endpoint{
+		{Domain: "example.com", Hostname: "www", Address: netip.MustParseAddr("2a00:1098:0:0::1"), Site: "all"},
+		{Domain: "example.com", Hostname: "api", Address: netip.MustParseAddr("2a00:1098::2"), Site: "all"},
+		{Domain: "example.org", Hostname: "@", Address: netip.MustParseAddr("2a00:1098::1"), Site: "sov", ProxyProtocol: true},
+		{Domain: "example.org", Hostname: "ipv4", Address: netip.MustParseAddr("192.0.2.1"), Site: "all"},
+	}
+
+	got := proxyEndpointsAt(endpoints, "2a00:1098::1")
+	want := []piProxyEndpoint{
+		{Domain: "example.com", Hostname: "www", Site: "all"},
+		{Domain: "example.org", Hostname: "@", Site: "sov", ProxyProtocol: true},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("proxyEndpointsAt() = %v, want %v", got, want)
+	}
+
+	if got := proxyEndpointsAt(endpoints, "2a00:1098::3"); len(got) != 0 {
+		t.Fatalf("expected no endpoints, got %v", got)
+	}
+}
+
+func TestCreatePiProxyEndpoints(t *testing.T) {
+	endpoints := []piProxyEndpoint{
+		{Domain: "example.com", Hostname: "www", Site: "hex"},
+		{Domain: "example.com", Hostname: "www", Site: "sov"},
+	}
+
+	t.Run("creates", func(t *testing.T) {
+		api := newFakeProxyEndpointAPI()
+
+		if err := createPiProxyEndpoints(context.Background(), api, "2a00:1098::1", endpoints); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if got := api.keys(); !reflect.DeepEqual(got, []string{"2a00:1098::1/hex", "2a00:1098::1/sov"}) {
+			t.Fatalf("expected both endpoints, got %v", got)
+		}
+	})
+
+	t.Run("removes the created endpoints when one fails", func(t *testing.T) {
+		api := newFakeProxyEndpointAPI()
+		api.failCreate["sov"] = true
+
+		err := createPiProxyEndpoints(context.Background(), api, "2a00:1098::1", endpoints)
+		if err == nil || !strings.Contains(err.Error(), "creating proxy endpoint example.com/www/sov") {
+			t.Fatalf("expected a create error, got %v", err)
+		}
+		if got := api.keys(); len(got) != 0 {
+			t.Fatalf("expected no endpoints after rolling back, got %v", got)
+		}
+	})
+
+	t.Run("reports a failed roll back", func(t *testing.T) {
+		api := newFakeProxyEndpointAPI()
+		api.failCreate["sov"] = true
+		api.failDelete["2a00:1098::1"] = true
+
+		err := createPiProxyEndpoints(context.Background(), api, "2a00:1098::1", endpoints)
+		if err == nil || !strings.Contains(err.Error(), "removing the endpoints already created") {
+			t.Fatalf("expected a roll back error, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,150 @@
+// Copyright IBM Corp. 2021, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/action/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/paultibbetts/mythicbeasts-client-go"
+	mbPi "github.com/paultibbetts/mythicbeasts-client-go/pi"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ action.Action              = &PiRebootAction{}
+	_ action.ActionWithConfigure = &PiRebootAction{}
+)
+
+// NewPiRebootAction is a helper function to simplify the provider implementation.
+func NewPiRebootAction() action.Action {
+	return &PiRebootAction{}
+}
+
+// PiRebootAction is the action implementation.
+type PiRebootAction struct {
+	client *mythicbeasts.Client
+}
+
+// PiRebootActionModel maps the action schema data.
+type PiRebootActionModel struct {
+	Identifier types.String `tfsdk:"identifier"`
+	Timeout    types.String `tfsdk:"timeout"`
+}
+
+// Metadata returns the action type name.
+func (a *PiRebootAction) Metadata(_ context.Context, req action.MetadataRequest, resp *action.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_pi_reboot"
+}
+
+// Schema defines the schema for the action.
+func (a *PiRebootAction) Schema(_ context.Context, _ action.SchemaRequest, resp *action.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Power cycles a [`mythicbeasts_pi` resource](../resources/pi) and waits for it to be powered on again, reporting the result as a warning. A Pi that stays powered on for 30 seconds after the reboot is accepted, since a quick reboot may not be seen going down.\n\n" +
+			"Run it with `terraform apply -invoke=action.mythicbeasts_pi_reboot.<name>` or from an `action_trigger` in a resource's `lifecycle` block.",
+		Attributes: map[string]schema.Attribute{
+			"identifier": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Identifier of the server",
+			},
+			"timeout": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "How long to wait for the Pi to be powered on again, as a duration such as `5m`\nDefault: `5m`",
+				Validators: []validator.String{
+					Duration(),
+				},
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the action.
+func (a *PiRebootAction) Configure(_ context.Context, req action.ConfigureRequest, resp *action.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*mythicbeasts.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Action Configure Type",
+			fmt.Sprintf("Expected *mythicbeasts.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	a.client = client
+}
+
+// Invoke reboots the Pi and waits for it to come back.
+func (a *PiRebootAction) Invoke(ctx context.Context, req action.InvokeRequest, resp *action.InvokeResponse) {
+	var config PiRebootActionModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	identifier := config.Identifier.ValueString()
+
+	timeout := piPowerTimeout
+	if !config.Timeout.IsNull() {
+		var err error
+		timeout, err = time.ParseDuration(config.Timeout.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Invalid timeout",
+				err.Error(),
+			)
+			return
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	resp.SendProgress(action.InvokeProgressEvent{
+		Message: fmt.Sprintf("Rebooting Pi %s", identifier),
+	})
+
+	if err := a.client.Pi().Reboot(ctx, identifier); err != nil {
+		resp.Diagnostics.AddError(
+			"Error rebooting Pi",
+			fmt.Sprintf("Could not reboot Pi %s, unexpected error: %s", identifier, err.Error()),
+		)
+		return
+	}
+
+	started := time.Now()
+	getServer := func(ctx context.Context) (mbPi.Server, error) {
+		return a.client.Pi().Get(ctx, identifier)
+	}
+
+	_, wentDown, err := waitForPiReboot(ctx, getServer, piRebootPollInterval, piRebootSettle)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error waiting for Pi",
+			fmt.Sprintf("Pi %s was asked to reboot but did not restart: %s", identifier, err.Error()),
+		)
+		return
+	}
+
+	result := fmt.Sprintf("Pi %s went down and is powered on again after %s.", identifier, time.Since(started).Round(time.Second))
+	if !wentDown {
+		result = fmt.Sprintf("Pi %s accepted the reboot and is powered on after %s. It was not seen going down, which is expected for a quick reboot.", identifier, time.Since(started).Round(time.Second))
+	}
+
+	resp.SendProgress(action.InvokeProgressEvent{
+		Message: result,
+	})
+	resp.Diagnostics.AddWarning("Pi rebooted", result)
+}
@@ -0,0 +1,69 @@
+// Copyright IBM Corp. 2021, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccPiRebootAction(t *testing.T) {
+	piIdentifier := testAccIdentifier("tfpirb", 20)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_14_0),
+		},
+		Steps: []resource.TestStep{
+			// Reboot the Pi once it has been created
+			{
+				Config: testAccPiRebootActionConfig(piIdentifier),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"mythicbeasts_pi."+piIdentifier,
+						tfjsonpath.New("power_state"),
+						knownvalue.StringExact("on"),
+					),
+				},
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccPiRebootActionConfig(identifier string) string {
+	return fmt.Sprintf(`
+resource "mythicbeasts_pi" %[1]q {
+  identifier = %[1]q
+  model      = 4
+  os_image   = "rpi-bookworm-arm64"
+  ssh_key    = %[2]q
+}
+
+action "mythicbeasts_pi_reboot" "test" {
+  config {
+    identifier = mythicbeasts_pi.%[1]s.identifier
+  }
+}
+
+resource "terraform_data" "trigger" {
+  input = mythicbeasts_pi.%[1]s.identifier
+
+  lifecycle {
+    action_trigger {
+      events  = [after_create]
+      actions = [action.mythicbeasts_pi_reboot.test]
+    }
+  }
+}
+`, identifier, piSSHKey)
+}
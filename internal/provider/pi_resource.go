@@ -10,8 +10,12 @@ import (
 	"fmt"
 	"regexp"
 	"strconv"
+	"strings"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -19,10 +23,13 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/paultibbetts/mythicbeasts-client-go"
 	mbPi "github.com/paultibbetts/mythicbeasts-client-go/pi"
@@ -33,6 +40,7 @@ var (
 	_ resource.Resource                = &PiResource{}
 	_ resource.ResourceWithConfigure   = &PiResource{}
 	_ resource.ResourceWithImportState = &PiResource{}
+	_ resource.ResourceWithModifyPlan  = &PiResource{}
 )
 
 // NewPiResource is a helper function to simplify the provider implementation.
@@ -47,18 +55,30 @@ type PiResource struct {
 
 // PiResourceModel maps the resource schema data.
 type PiResourceModel struct {
-	Identifier types.String `tfsdk:"identifier"`
-	DiskSize   types.Int64  `tfsdk:"disk_size"`
-	SSHKey     types.String `tfsdk:"ssh_key"`
-	Model      types.Int64  `tfsdk:"model"`
-	Memory     types.Int64  `tfsdk:"memory"`
-	CPUSpeed   types.Int64  `tfsdk:"cpu_speed"`
-	NICSpeed   types.Int64  `tfsdk:"nic_speed"`
-	OSImage    types.String `tfsdk:"os_image"`
-	WaitForDNS types.Bool   `tfsdk:"wait_for_dns"`
-	IP         types.String `tfsdk:"ip"`
-	SSHPort    types.Int64  `tfsdk:"ssh_port"`
-	Location   types.String `tfsdk:"location"`
+	Identifier    types.String `tfsdk:"identifier"`
+	DiskSize      types.Int64  `tfsdk:"disk_size"`
+	SSHKey        types.String `tfsdk:"ssh_key"`
+	SSHKeyVersion types.Int64  `tfsdk:"ssh_key_version"`
+	SSHKeys       types.List   `tfsdk:"ssh_keys"`
+	Fingerprints  types.List   `tfsdk:"ssh_key_fingerprints"`
+	Model         types.Int64  `tfsdk:"model"`
+	Memory        types.Int64  `tfsdk:"memory"`
+	CPUSpeed      types.Int64  `tfsdk:"cpu_speed"`
+	NICSpeed      types.Int64  `tfsdk:"nic_speed"`
+	OSImage       types.String `tfsdk:"os_image"`
+	Reimage       types.Bool   `tfsdk:"reimage_on_change"`
+	WaitForDNS    types.Bool   `tfsdk:"wait_for_dns"`
+	DNSWait       types.Object `tfsdk:"dns_wait"`
+	IP            types.String `tfsdk:"ip"`
+	SSHPort       types.Int64  `tfsdk:"ssh_port"`
+	Location      types.String `tfsdk:"location"`
+	PowerState    types.String `tfsdk:"power_state"`
+
+	ProxyEndpoints       types.Set    `tfsdk:"proxy_endpoints"`
+	ProxyEndpointAddress types.String `tfsdk:"proxy_endpoint_address"`
+	DeleteProxyEndpoints types.Bool   `tfsdk:"delete_proxy_endpoints_on_destroy"`
+
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
 }
 
 // Metadata returns the resource type name.
@@ -67,10 +87,12 @@ func (r *PiResource) Metadata(_ context.Context, req resource.MetadataRequest, r
 }
 
 // Schema defines the schema for the resource.
-func (r *PiResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+func (r *PiResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		MarkdownDescription: "Manages a Raspberry Pi.\n\n" +
 			"~> **Note:** This is a service aimed at hobbyists, and shouldn't be used for nuclear power station command and control systems.\n\n" +
+			"## Provisioning\n\n" +
+			"Provisioning a Pi can take several minutes. The Pi is saved to state as soon as it has been requested, so if it is not ready within the `create` timeout it is marked as tainted and replaced on the next apply rather than left behind.\n\n" +
 			"## IPv6\n\n" +
 			"The Pis are on an IPv6-only network. See [`mythicbeasts_proxy_endpoint` resource](../resources/proxy_endpoint) to set up an endpoint for the IPv4 to IPv6 proxy.",
 		Attributes: map[string]schema.Attribute{
@@ -103,12 +125,37 @@ func (r *PiResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *r
 			"ssh_key": schema.StringAttribute{
 				Optional:            true,
 				WriteOnly:           true,
-				MarkdownDescription: "Public SSH key(s) to be added to /root/.ssh/authorized_keys on server",
+				MarkdownDescription: "Public SSH key(s) to be added to /root/.ssh/authorized_keys on server\n\nAfter the Pi has been created the key is only sent to the API when `ssh_key_version` changes. Use `ssh_keys` instead to manage a list of keys.",
 				Validators: []validator.String{
 					stringvalidator.RegexMatches(
 						regexp.MustCompile(`\S`),
 						"must not be empty or whitespace",
 					),
+					stringvalidator.ConflictsWith(path.MatchRoot("ssh_keys")),
+				},
+			},
+			"ssh_keys": schema.ListAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+					listvalidator.ValueStringsAre(SSHPublicKey()),
+				},
+				MarkdownDescription: "Public SSH keys to be added to /root/.ssh/authorized_keys on server, one per entry. Repeated keys are only installed once.\n\nChanging the list updates the keys on the Pi in place.",
+			},
+			"ssh_key_fingerprints": schema.ListAttribute{
+				Computed:    true,
+				ElementType: types.StringType,
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.UseStateForUnknown(),
+				},
+				MarkdownDescription: "SHA256 fingerprints of the SSH keys installed on the Pi by `ssh_keys` or `ssh_key`",
+			},
+			"ssh_key_version": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: "Version of `ssh_key`. The SSH key is only sent to the API when this value changes",
+				Validators: []validator.Int64{
+					int64validator.AlsoRequires(path.MatchRoot("ssh_key")),
 				},
 			},
 			"model": schema.Int64Attribute{
@@ -118,10 +165,7 @@ func (r *PiResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *r
 				PlanModifiers: []planmodifier.Int64{
 					int64planmodifier.RequiresReplace(),
 				},
-				Validators: []validator.Int64{
-					int64validator.OneOf(3, 4),
-				},
-				MarkdownDescription: "Raspberry Pi model (3 or 4)",
+				MarkdownDescription: "Raspberry Pi model, such as 3 or 4. Must be one of the models returned by the [`mythicbeasts_pi_models` data source](../data-sources/pi_models).",
 			},
 			"memory": schema.Int64Attribute{
 				Computed: true,
@@ -149,21 +193,58 @@ func (r *PiResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *r
 			},
 			"os_image": schema.StringAttribute{
 				Optional:            true,
-				MarkdownDescription: "Operating system image",
+				MarkdownDescription: "Operating system image\n\nChanging this replaces the Pi, unless `reimage_on_change` is `true`.",
 				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplaceIfConfigured(),
+					stringplanmodifier.RequiresReplaceIf(
+						requiresReplaceUnlessReimage,
+						"Changing the OS image replaces the Pi unless reimage_on_change is true.",
+						"Changing the OS image replaces the Pi unless `reimage_on_change` is `true`.",
+					),
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"delete_proxy_endpoints_on_destroy": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Whether destroying the Pi also removes every proxy endpoint pointing at its `ip`, including endpoints not managed by Terraform, so they don't proxy to whichever server is given the address next.\nDefault: `false`",
+			},
+			"reimage_on_change": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Whether a change to `os_image` reimages the Pi in place, keeping its identifier and IPv6 address, instead of replacing it. Reimaging erases the disk.\nDefault: `false`",
+			},
 			"wait_for_dns": schema.BoolAttribute{
 				Optional:            true,
 				WriteOnly:           true,
-				MarkdownDescription: "Whether to wait for DNS records under hostedpi.com to become available before completing provisioning.",
+				MarkdownDescription: "Whether to wait for DNS records under hostedpi.com to become available before completing provisioning. The provider resolves `{identifier}.hostedpi.com` and `ssh.{identifier}.hostedpi.com` and waits until both have an AAAA record for `ip` and an A record; see `dns_wait` to configure the wait.",
 				PlanModifiers: []planmodifier.Bool{
 					boolplanmodifier.RequiresReplace(),
 					boolplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"dns_wait": schema.SingleNestedAttribute{
+				Optional: true,
+				Attributes: map[string]schema.Attribute{
+					"resolver": schema.StringAttribute{
+						Optional: true,
+						Validators: []validator.String{
+							stringvalidator.RegexMatches(
+								regexp.MustCompile(`^\S+$`),
+								"must not be empty or contain whitespace",
+							),
+						},
+						MarkdownDescription: "Address of the DNS server to query, as `host` or `host:port`\nDefault: the system resolver",
+					},
+					"timeout": schema.StringAttribute{
+						Optional: true,
+						Computed: true,
+						Default:  stringdefault.StaticString(defaultPiDNSWaitTimeout),
+						Validators: []validator.String{
+							Duration(),
+						},
+						MarkdownDescription: "How long to wait for the records, as a duration such as `30s` or `10m`\nDefault: `" + defaultPiDNSWaitTimeout + "`",
+					},
+				},
+				MarkdownDescription: "Settings for the DNS wait enabled by `wait_for_dns`. If the wait times out the Pi is kept in state and marked as tainted.",
+			},
 			"ip": schema.StringAttribute{
 				Computed:            true,
 				MarkdownDescription: "IPv6 address for server",
@@ -178,6 +259,17 @@ func (r *PiResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *r
 					int64planmodifier.UseStateForUnknown(),
 				},
 			},
+			"power_state": schema.StringAttribute{
+				Computed:            true,
+				Optional:            true,
+				MarkdownDescription: "Whether the Pi is powered `on` or `off`. Changing this powers the Pi on or off in place and waits for it to reach the new state. Use the [`mythicbeasts_pi_reboot` action](../actions/pi_reboot) to power cycle a Pi.",
+				Validators: []validator.String{
+					stringvalidator.OneOf(piPowerOn, piPowerOff),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
 			"location": schema.StringAttribute{
 				Computed:            true,
 				MarkdownDescription: "Data centre in which server is located",
@@ -186,6 +278,24 @@ func (r *PiResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *r
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"proxy_endpoints": piProxyEndpointsSchema(),
+			"proxy_endpoint_address": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "IPv6 address the `proxy_endpoints` point at.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create:            true,
+				Update:            true,
+				Delete:            true,
+				CreateDescription: "How long to wait for the Pi to be provisioned. Default: `30m`",
+				UpdateDescription: "How long to wait for the Pi to be reimaged. Default: `30m`",
+				DeleteDescription: "How long to wait for the Pi to be deleted. Default: `10m`",
+			}),
 		},
 	}
 }
@@ -212,6 +322,71 @@ func (r *PiResource) Configure(_ context.Context, req resource.ConfigureRequest,
 	r.client = client
 }
 
+// ModifyPlan validates the requested Pi specification against the models
+// that are available and plans the specification the Pi will be given.
+func (r *PiResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to do on destroy, or before the provider is configured.
+	if req.Plan.Raw.IsNull() || r.client == nil {
+		return
+	}
+
+	var config PiResourceModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var plan PiResourceModel
+	diags = req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if req.State.Raw.IsNull() {
+		r.modifyPlanProxyEndpoints(ctx, plan, nil, resp)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		r.modifyPlanSSHKeys(ctx, config, plan, nil, resp)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	} else {
+		var state PiResourceModel
+		diags = req.State.Get(ctx, &state)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		r.modifyPlanProxyEndpoints(ctx, plan, &state, resp)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		r.modifyPlanSSHKeys(ctx, config, plan, &state, resp)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		// An existing Pi keeps its specification unless the configuration
+		// asks for a different one.
+		if plan.Model.Equal(state.Model) &&
+			(config.Memory.IsNull() || config.Memory.Equal(state.Memory)) &&
+			(config.CPUSpeed.IsNull() || config.CPUSpeed.Equal(state.CPUSpeed)) {
+			resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("memory"), state.Memory)...)
+			resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("cpu_speed"), state.CPUSpeed)...)
+			resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("nic_speed"), state.NICSpeed)...)
+			return
+		}
+	}
+
+	r.modifyPlanModel(ctx, config, plan, resp)
+}
+
 // Create creates the resource and sets the initial Terraform state.
 func (r *PiResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	// Retrieve values from plan
@@ -233,9 +408,12 @@ func (r *PiResource) Create(ctx context.Context, req resource.CreateRequest, res
 
 	identifier := plan.Identifier.ValueString()
 
-	if !config.SSHKey.IsNull() && !config.SSHKey.IsUnknown() {
-		Pi.SSHKey = config.SSHKey.ValueString()
+	sshKeys, fingerprints, _, diags := piSSHKeys(ctx, config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
+	Pi.SSHKey = sshKeys
 
 	if !plan.Model.IsNull() && !plan.Model.IsUnknown() {
 		Pi.Model = plan.Model.ValueInt64()
@@ -276,8 +454,17 @@ func (r *PiResource) Create(ctx context.Context, req resource.CreateRequest, res
 		}
 	}
 
-	// Create new server
-	server, err := r.client.Pi().Create(ctx, identifier, Pi)
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultPiCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	// Request the new server
+	err = r.client.Pi().StartCreate(ctx, identifier, Pi)
 	if err != nil {
 		var identifierConflictErr *mbPi.ErrIdentifierConflict
 		if errors.As(err, &identifierConflictErr) {
@@ -295,37 +482,67 @@ func (r *PiResource) Create(ctx context.Context, req resource.CreateRequest, res
 		return
 	}
 
-	// When creating it's `Disk` as an int
-	// After creation it's `DiskSize` as a string
-	diskSize, err := strconv.ParseFloat(server.DiskSize, 64)
+	// Save the server as soon as it exists, so that it is tracked, and
+	// tainted, if provisioning fails or times out.
+	state := plan
+	state.Memory = knownInt64OrNull(plan.Memory)
+	state.CPUSpeed = knownInt64OrNull(plan.CPUSpeed)
+	state.NICSpeed = types.Int64Null()
+	state.IP = types.StringNull()
+	state.SSHPort = types.Int64Null()
+	state.Location = types.StringNull()
+	state.PowerState = types.StringNull()
+	state.WaitForDNS = types.BoolValue(waitForDNS)
+	state.ProxyEndpoints = types.SetNull(piProxyEndpointType)
+	state.ProxyEndpointAddress = types.StringNull()
+	state.Fingerprints = fingerprints
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("waiting up to %s for Pi %s to be provisioned", createTimeout, identifier))
+
+	if err := waitForPiProvisioning(ctx, r.client, identifier, piWaitPollInterval); err != nil {
+		resp.Diagnostics.AddError(
+			"Error waiting for Pi server",
+			fmt.Sprintf("Pi server %s was requested but is not ready: %s", identifier, err.Error()),
+		)
+		return
+	}
+
+	server, err := r.client.Pi().Get(ctx, identifier)
 	if err != nil {
 		resp.Diagnostics.AddError(
-			"Error creating Pi server",
-			"Could not create Pi server, unexpected error converting disk size: "+err.Error(),
+			"Error reading Mythic Beasts Pi",
+			"Could not read Pi "+state.Identifier.String()+": "+err.Error(),
 		)
 		return
 	}
 
-	state := plan
+	if !plan.PowerState.IsNull() && !plan.PowerState.IsUnknown() && plan.PowerState.ValueString() != piPowerState(server) {
+		server, err = r.setPower(ctx, identifier, plan.PowerState.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("power_state"),
+				"Error changing Pi power state",
+				fmt.Sprintf("Pi server %s was created but could not be powered %s: %s", identifier, plan.PowerState.ValueString(), err.Error()),
+			)
+			return
+		}
+	}
 
 	// Map response body to schema and populate Computed attribute values
-
-	state.Memory = types.Int64Value(server.Memory)
-	state.CPUSpeed = types.Int64Value(server.CPUSpeed)
-	state.NICSpeed = types.Int64Value(server.NICSpeed)
-	ip, err := normalizeIPv6(server.IP)
-	if err != nil {
+	if err := state.readServer(server); err != nil {
 		resp.Diagnostics.AddError(
 			"Error creating Pi server",
-			fmt.Sprintf("Could not create Pi server, invalid IPv6 address %q: %s", server.IP, err.Error()),
+			"Could not create Pi server, unexpected error: "+err.Error(),
 		)
 		return
 	}
-	state.IP = types.StringValue(ip)
-	state.SSHPort = types.Int64Value(server.SSHPort)
-	state.DiskSize = types.Int64Value(int64(diskSize))
-	state.Location = types.StringValue(server.Location)
-	state.Model = types.Int64Value(server.Model)
+	ip := state.IP.ValueString()
 	state.WaitForDNS = types.BoolValue(waitForDNS)
 
 	// Set state to fully populated data
@@ -334,6 +551,109 @@ func (r *PiResource) Create(ctx context.Context, req resource.CreateRequest, res
 	if resp.Diagnostics.HasError() {
 		return
 	}
+
+	if waitForDNS {
+		r.waitForDNS(ctx, identifier, ip, plan.DNSWait, resp)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	endpoints, diags := piProxyEndpointsFromValue(ctx, plan.ProxyEndpoints)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if len(endpoints) == 0 {
+		state.ProxyEndpoints = plan.ProxyEndpoints
+		resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+		return
+	}
+
+	if err := createPiProxyEndpoints(ctx, r.client.Proxy(), ip, endpoints); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("proxy_endpoints"),
+			"Error creating Pi proxy endpoints",
+			fmt.Sprintf("Pi server %s was created but its proxy endpoints could not be created: %s", identifier, err.Error()),
+		)
+		return
+	}
+
+	state.ProxyEndpoints = plan.ProxyEndpoints
+	state.ProxyEndpointAddress = types.StringValue(ip)
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// waitForDNS waits for the Pi's hostedpi.com records to resolve to its
+// address, using the resolver and timeout from dns_wait.
+func (r *PiResource) waitForDNS(ctx context.Context, identifier string, ip string, dnsWait types.Object, resp *resource.CreateResponse) {
+	settings := PiDNSWaitModel{
+		Resolver: types.StringNull(),
+		Timeout:  types.StringValue(defaultPiDNSWaitTimeout),
+	}
+	if !dnsWait.IsNull() && !dnsWait.IsUnknown() {
+		resp.Diagnostics.Append(dnsWait.As(ctx, &settings, basetypes.ObjectAsOptions{})...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	timeout, err := time.ParseDuration(settings.Timeout.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("dns_wait").AtName("timeout"),
+			"Invalid DNS wait timeout",
+			err.Error(),
+		)
+		return
+	}
+
+	resolver := newDNSResolver(settings.Resolver.ValueString())
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	tflog.Info(ctx, fmt.Sprintf("waiting up to %s for DNS records for Pi %s", timeout, identifier))
+
+	if err := waitForPiDNS(ctx, resolver, identifier, ip, piDNSWaitPollInterval); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("wait_for_dns"),
+			"Error waiting for Pi DNS records",
+			fmt.Sprintf("Pi server %s was created but its DNS records are not ready: %s", identifier, err.Error()),
+		)
+		return
+	}
+}
+
+// readServer sets the attributes that the API reports for the server.
+func (m *PiResourceModel) readServer(server mbPi.Server) error {
+	diskSize, err := strconv.ParseFloat(server.DiskSize, 64)
+	if err != nil {
+		return fmt.Errorf("converting disk size %q: %w", server.DiskSize, err)
+	}
+
+	ip, err := normalizeIPv6(server.IP)
+	if err != nil {
+		return fmt.Errorf("invalid IPv6 address %q: %w", server.IP, err)
+	}
+
+	m.Memory = types.Int64Value(server.Memory)
+	m.CPUSpeed = types.Int64Value(server.CPUSpeed)
+	m.NICSpeed = types.Int64Value(server.NICSpeed)
+	m.IP = types.StringValue(ip)
+	m.SSHPort = types.Int64Value(server.SSHPort)
+	m.DiskSize = types.Int64Value(int64(diskSize))
+	m.Location = types.StringValue(server.Location)
+	m.Model = types.Int64Value(server.Model)
+	m.PowerState = types.StringValue(piPowerState(server))
+
+	return nil
 }
 
 // Read refreshes the Terraform state with the latest data.
@@ -354,35 +674,40 @@ func (r *PiResource) Read(ctx context.Context, req resource.ReadRequest, resp *r
 		return
 	}
 
-	diskSize, err := strconv.ParseFloat(server.DiskSize, 64)
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error reading Mythic Beasts Pi",
-			"Could not read Pi, unexpected error converting disk size: "+err.Error(),
-		)
-		return
-	}
-
 	tflog.Warn(ctx, fmt.Sprintf("memory from aPi: %d - memory from state: %d", server.Memory, state.Memory.ValueInt64()))
 	tflog.Warn(ctx, fmt.Sprintf("ssh port from aPi: %d - ssh port from state: %d", server.SSHPort, state.SSHPort.ValueInt64()))
 	tflog.Warn(ctx, fmt.Sprintf("location from aPi: %s - location from state: %s", server.Location, state.Location.ValueString()))
 
-	state.Memory = types.Int64Value(server.Memory)
-	state.CPUSpeed = types.Int64Value(server.CPUSpeed)
-	state.NICSpeed = types.Int64Value(server.NICSpeed)
-	ip, err := normalizeIPv6(server.IP)
-	if err != nil {
+	if err := state.readServer(server); err != nil {
 		resp.Diagnostics.AddError(
 			"Error reading Mythic Beasts Pi",
-			fmt.Sprintf("Could not read Pi %s, invalid IPv6 address %q: %s", state.Identifier.String(), server.IP, err.Error()),
+			fmt.Sprintf("Could not read Pi %s: %s", state.Identifier.String(), err.Error()),
 		)
 		return
 	}
-	state.IP = types.StringValue(ip)
-	state.SSHPort = types.Int64Value(server.SSHPort)
-	state.DiskSize = types.Int64Value(int64(diskSize))
-	state.Location = types.StringValue(server.Location)
-	state.Model = types.Int64Value(server.Model)
+
+	if !state.ProxyEndpointAddress.IsNull() {
+		endpoints, diags := piProxyEndpointsFromValue(ctx, state.ProxyEndpoints)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		endpoints, err = readPiProxyEndpoints(ctx, r.client, state.ProxyEndpointAddress.ValueString(), endpoints)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error reading Pi proxy endpoints",
+				fmt.Sprintf("Could not read proxy endpoints for Pi %s: %s", state.Identifier.String(), err.Error()),
+			)
+			return
+		}
+
+		state.ProxyEndpoints, diags = piProxyEndpointsValue(endpoints)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
 
 	diags = resp.State.Set(ctx, &state)
 	resp.Diagnostics.Append(diags...)
@@ -407,9 +732,46 @@ func (r *PiResource) Update(ctx context.Context, req resource.UpdateRequest, res
 		return
 	}
 
-	if !config.SSHKey.IsNull() && !config.SSHKey.IsUnknown() {
+	var plan PiResourceModel
+	diags = req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	reimaged := false
+	if plan.Reimage.ValueBool() && !plan.OSImage.IsNull() && !plan.OSImage.Equal(state.OSImage) {
+		r.reimage(ctx, plan, config, resp)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		reimaged = true
+	}
+
+	sshKeyChanged := !plan.SSHKeyVersion.Equal(state.SSHKeyVersion) || !plan.SSHKeys.Equal(state.SSHKeys)
+
+	sshKeys, fingerprints, hasSSHKeys, diags := piSSHKeys(ctx, config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state.OSImage = plan.OSImage
+	state.Reimage = plan.Reimage
+	state.DeleteProxyEndpoints = plan.DeleteProxyEndpoints
+	state.SSHKeyVersion = plan.SSHKeyVersion
+	state.SSHKeys = plan.SSHKeys
+	state.Timeouts = plan.Timeouts
+	state.DNSWait = plan.DNSWait
+
+	if reimaged && hasSSHKeys {
+		state.Fingerprints = fingerprints
+	}
+
+	// A reimage installs the configured SSH keys.
+	if !reimaged && sshKeyChanged && hasSSHKeys {
 		requestBody := mbPi.UpdateSSHKeyRequest{
-			SSHKey: config.SSHKey.ValueString(),
+			SSHKey: sshKeys,
 		}
 
 		_, err := r.client.Pi().UpdateSSHKey(ctx, state.Identifier.ValueString(), requestBody)
@@ -420,6 +782,20 @@ func (r *PiResource) Update(ctx context.Context, req resource.UpdateRequest, res
 			)
 			return
 		}
+
+		state.Fingerprints = fingerprints
+	}
+
+	if !config.PowerState.IsNull() && !config.PowerState.IsUnknown() && !config.PowerState.Equal(state.PowerState) {
+		_, err := r.setPower(ctx, state.Identifier.ValueString(), config.PowerState.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("power_state"),
+				"Error changing Pi power state",
+				fmt.Sprintf("Could not power %s Pi %s: %s", config.PowerState.ValueString(), state.Identifier.String(), err.Error()),
+			)
+			return
+		}
 	}
 
 	server, err := r.client.Pi().Get(ctx, state.Identifier.ValueString())
@@ -431,31 +807,18 @@ func (r *PiResource) Update(ctx context.Context, req resource.UpdateRequest, res
 		return
 	}
 
-	diskSize, err := strconv.ParseFloat(server.DiskSize, 64)
-	if err != nil {
+	if err := state.readServer(server); err != nil {
 		resp.Diagnostics.AddError(
 			"Error reading Mythic Beasts Pi",
-			"Could not read Pi, unexpected error converting disk size: "+err.Error(),
+			fmt.Sprintf("Could not read Pi %s: %s", state.Identifier.String(), err.Error()),
 		)
 		return
 	}
 
-	state.Memory = types.Int64Value(server.Memory)
-	state.CPUSpeed = types.Int64Value(server.CPUSpeed)
-	state.NICSpeed = types.Int64Value(server.NICSpeed)
-	ip, err := normalizeIPv6(server.IP)
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error reading Mythic Beasts Pi",
-			fmt.Sprintf("Could not read Pi %s, invalid IPv6 address %q: %s", state.Identifier.String(), server.IP, err.Error()),
-		)
+	r.updateProxyEndpoints(ctx, plan, &state, resp)
+	if resp.Diagnostics.HasError() {
 		return
 	}
-	state.IP = types.StringValue(ip)
-	state.SSHPort = types.Int64Value(server.SSHPort)
-	state.DiskSize = types.Int64Value(int64(diskSize))
-	state.Location = types.StringValue(server.Location)
-	state.Model = types.Int64Value(server.Model)
 
 	diags = resp.State.Set(ctx, &state)
 	resp.Diagnostics.Append(diags...)
@@ -464,6 +827,36 @@ func (r *PiResource) Update(ctx context.Context, req resource.UpdateRequest, res
 	}
 }
 
+// updateProxyEndpoints reconciles the inline proxy endpoints with the plan,
+// moving them to the Pi's current address, and records them in state.
+func (r *PiResource) updateProxyEndpoints(ctx context.Context, plan PiResourceModel, state *PiResourceModel, resp *resource.UpdateResponse) {
+	current, diags := piProxyEndpointsFromValue(ctx, state.ProxyEndpoints)
+	resp.Diagnostics.Append(diags...)
+	desired, diags := piProxyEndpointsFromValue(ctx, plan.ProxyEndpoints)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	oldAddress := state.ProxyEndpointAddress.ValueString()
+	newAddress := state.IP.ValueString()
+
+	if err := reconcilePiProxyEndpoints(ctx, r.client.Proxy(), oldAddress, current, newAddress, desired); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("proxy_endpoints"),
+			"Error updating Pi proxy endpoints",
+			fmt.Sprintf("Could not update proxy endpoints for Pi %s: %s", state.Identifier.String(), err.Error()),
+		)
+		return
+	}
+
+	state.ProxyEndpoints = plan.ProxyEndpoints
+	state.ProxyEndpointAddress = types.StringNull()
+	if len(desired) > 0 {
+		state.ProxyEndpointAddress = types.StringValue(newAddress)
+	}
+}
+
 // Delete deletes the resource and removes the Terraform state on success.
 func (r *PiResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
 	var state PiResourceModel
@@ -473,6 +866,37 @@ func (r *PiResource) Delete(ctx context.Context, req resource.DeleteRequest, res
 		return
 	}
 
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultPiDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	if !state.ProxyEndpointAddress.IsNull() {
+		endpoints, diags := piProxyEndpointsFromValue(ctx, state.ProxyEndpoints)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		if err := deletePiProxyEndpoints(ctx, r.client.Proxy(), state.ProxyEndpointAddress.ValueString(), endpoints); err != nil {
+			resp.Diagnostics.AddWarning(
+				"Error deleting Pi proxy endpoints",
+				fmt.Sprintf("The proxy endpoints for Pi %s may need to be removed manually: %s", state.Identifier.String(), err.Error()),
+			)
+		}
+	}
+
+	if state.DeleteProxyEndpoints.ValueBool() && !state.IP.IsNull() {
+		r.deleteDependentProxyEndpoints(ctx, state, resp)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
 	err := r.client.Pi().Delete(ctx, state.Identifier.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -481,6 +905,133 @@ func (r *PiResource) Delete(ctx context.Context, req resource.DeleteRequest, res
 		)
 		return
 	}
+
+	getServer := func(ctx context.Context) (mbPi.Server, error) {
+		return r.client.Pi().Get(ctx, state.Identifier.ValueString())
+	}
+
+	if err := waitForPiDeleted(ctx, state.Identifier.ValueString(), getServer, piWaitPollInterval); err != nil {
+		resp.Diagnostics.AddError(
+			"Error waiting for Pi to be deleted",
+			fmt.Sprintf("Pi %s was asked to be deleted but is still present: %s", state.Identifier.String(), err.Error()),
+		)
+		return
+	}
+}
+
+// deleteDependentProxyEndpoints removes every proxy endpoint pointing at
+// the Pi's address, warning about each one it removed.
+func (r *PiResource) deleteDependentProxyEndpoints(ctx context.Context, state PiResourceModel, resp *resource.DeleteResponse) {
+	address, err := normalizeIPv6(state.IP.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error deleting Pi proxy endpoints",
+			fmt.Sprintf("Could not parse the address of Pi %s: %s", state.Identifier.String(), err.Error()),
+		)
+		return
+	}
+
+	removed, err := deleteProxyEndpointsAt(ctx, r.client, address)
+
+	if len(removed) > 0 {
+		keys := make([]string, 0, len(removed))
+		for _, endpoint := range removed {
+			keys = append(keys, endpoint.key())
+		}
+
+		resp.Diagnostics.AddWarning(
+			"Removed Pi proxy endpoints",
+			fmt.Sprintf("Removed the proxy endpoints pointing at %s:\n\n%s", address, strings.Join(keys, "\n")),
+		)
+	}
+
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error deleting Pi proxy endpoints",
+			fmt.Sprintf("Could not remove the proxy endpoints pointing at Pi %s, so the Pi was not deleted: %s", state.Identifier.String(), err.Error()),
+		)
+	}
+}
+
+// reimage installs the planned OS image on the Pi in place and waits for
+// it to be provisioned again.
+func (r *PiResource) reimage(ctx context.Context, plan PiResourceModel, config PiResourceModel, resp *resource.UpdateResponse) {
+	identifier := plan.Identifier.ValueString()
+
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultPiCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	sshKeys, _, _, diags := piSSHKeys(ctx, config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	request := mbPi.ReimageRequest{
+		OSImage: plan.OSImage.ValueString(),
+		SSHKey:  sshKeys,
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("reimaging Pi %s with %s", identifier, request.OSImage))
+
+	if err := r.client.Pi().Reimage(ctx, identifier, request); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("os_image"),
+			"Error reimaging Pi",
+			fmt.Sprintf("Could not reimage Pi %s with %s, unexpected error: %s", identifier, request.OSImage, err.Error()),
+		)
+		return
+	}
+
+	if err := waitForPiProvisioning(ctx, r.client, identifier, piWaitPollInterval); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("os_image"),
+			"Error waiting for Pi",
+			fmt.Sprintf("Pi %s was reimaged with %s but is not ready: %s", identifier, request.OSImage, err.Error()),
+		)
+		return
+	}
+}
+
+// requiresReplaceUnlessReimage replaces the Pi when a configured os_image
+// changes, unless reimage_on_change is set.
+func requiresReplaceUnlessReimage(ctx context.Context, req planmodifier.StringRequest, resp *stringplanmodifier.RequiresReplaceIfFuncResponse) {
+	if req.ConfigValue.IsNull() {
+		return
+	}
+
+	var reimage types.Bool
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("reimage_on_change"), &reimage)...)
+
+	resp.RequiresReplace = !reimage.ValueBool()
+}
+
+// setPower powers a Pi on or off and waits for it to reach that state.
+func (r *PiResource) setPower(ctx context.Context, identifier string, want string) (mbPi.Server, error) {
+	ctx, cancel := context.WithTimeout(ctx, piPowerTimeout)
+	defer cancel()
+
+	tflog.Info(ctx, fmt.Sprintf("powering %s Pi %s", want, identifier))
+
+	if _, err := r.client.Pi().SetPower(ctx, identifier, want == piPowerOn); err != nil {
+		return mbPi.Server{}, err
+	}
+
+	return waitForPiPower(ctx, r.client, identifier, want, piWaitPollInterval)
+}
+
+func knownInt64OrNull(value types.Int64) types.Int64 {
+	if value.IsUnknown() {
+		return types.Int64Null()
+	}
+
+	return value
 }
 
 func (r *PiResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
@@ -7,8 +7,10 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-testing/compare"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
 	"github.com/hashicorp/terraform-plugin-testing/statecheck"
 	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
 )
@@ -26,7 +28,7 @@ func TestAccPiResource(t *testing.T) {
 		Steps: []resource.TestStep{
 			// Create and Read testing
 			{
-				Config: testAccPiResourceConfig(piIdentifier, piSSHKey),
+				Config: testAccPiResourceConfig(piIdentifier, piSSHKey, 1),
 				ConfigStateChecks: []statecheck.StateCheck{
 					statecheck.ExpectKnownValue(
 						resourceAddress,
@@ -53,6 +55,11 @@ func TestAccPiResource(t *testing.T) {
 						tfjsonpath.New("memory"),
 						knownvalue.Int64Exact(4096),
 					),
+					statecheck.ExpectKnownValue(
+						resourceAddress,
+						tfjsonpath.New("power_state"),
+						knownvalue.StringExact("on"),
+					),
 				},
 			},
 			// ImportState testing
@@ -65,12 +72,23 @@ func TestAccPiResource(t *testing.T) {
 				ImportStateVerifyIgnore: []string{
 					"os_image",
 					"wait_for_dns",
+					"timeouts",
 				},
 			},
 			// Update and Read testing
 			{
-				Config: testAccPiResourceConfig(piIdentifier, piSSHKeyUpdated),
+				Config: testAccPiResourceConfig(piIdentifier, piSSHKeyUpdated, 2),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction(resourceAddress, plancheck.ResourceActionUpdate),
+					},
+				},
 				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						resourceAddress,
+						tfjsonpath.New("ssh_key_version"),
+						knownvalue.Int64Exact(2),
+					),
 					statecheck.ExpectKnownValue(
 						resourceAddress,
 						tfjsonpath.New("identifier"),
@@ -103,7 +121,131 @@ func TestAccPiResource(t *testing.T) {
 	})
 }
 
-func testAccPiResourceConfig(identifier string, sshKey string) string {
+func testAccPiResourceConfig(identifier string, sshKey string, sshKeyVersion int) string {
+	return fmt.Sprintf(`
+resource "mythicbeasts_pi" %[1]q {
+  identifier      = %[1]q
+  disk_size       = 10
+  model           = 4
+  os_image        = "rpi-bookworm-arm64"
+  ssh_key         = %[2]q
+  ssh_key_version = %[3]d
+  wait_for_dns    = true
+  memory          = 4096
+
+  timeouts {
+    create = "20m"
+    delete = "5m"
+  }
+}
+`, identifier, sshKey, sshKeyVersion)
+}
+
+func TestAccPiResourceReimage(t *testing.T) {
+	piIdentifier := testAccIdentifier("tfpi", 20)
+	resourceAddress := "mythicbeasts_pi." + piIdentifier
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccPiResourceReimageConfig(piIdentifier, "rpi-bullseye-arm64"),
+			},
+			// Changing os_image reimages the Pi in place
+			{
+				Config: testAccPiResourceReimageConfig(piIdentifier, "rpi-bookworm-arm64"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction(resourceAddress, plancheck.ResourceActionUpdate),
+					},
+				},
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						resourceAddress,
+						tfjsonpath.New("os_image"),
+						knownvalue.StringExact("rpi-bookworm-arm64"),
+					),
+				},
+			},
+		},
+	})
+}
+
+func testAccPiResourceReimageConfig(identifier string, osImage string) string {
+	return fmt.Sprintf(`
+resource "mythicbeasts_pi" %[1]q {
+  identifier        = %[1]q
+  disk_size         = 10
+  model             = 4
+  os_image          = %[2]q
+  reimage_on_change = true
+  ssh_key           = %[3]q
+  memory            = 4096
+}
+`, identifier, osImage, piSSHKey)
+}
+
+func TestAccPiResourceProxyEndpoints(t *testing.T) {
+	piIdentifier := testAccIdentifier("tfpp", 20)
+	domain := testAccProxyEndpointDomain(t)
+	resourceAddress := "mythicbeasts_pi." + piIdentifier
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create with an inline proxy endpoint
+			{
+				Config: testAccPiResourceProxyEndpointsConfig(piIdentifier, domain, false),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						resourceAddress,
+						tfjsonpath.New("proxy_endpoints"),
+						knownvalue.SetExact([]knownvalue.Check{
+							knownvalue.ObjectExact(map[string]knownvalue.Check{
+								"domain":         knownvalue.StringExact(domain),
+								"hostname":       knownvalue.StringExact(piIdentifier),
+								"site":           knownvalue.StringExact("all"),
+								"proxy_protocol": knownvalue.Bool(false),
+							}),
+						}),
+					),
+					statecheck.CompareValuePairs(
+						resourceAddress,
+						tfjsonpath.New("proxy_endpoint_address"),
+						resourceAddress,
+						tfjsonpath.New("ip"),
+						compare.ValuesSame(),
+					),
+				},
+			},
+			// Update the endpoint in place
+			{
+				Config: testAccPiResourceProxyEndpointsConfig(piIdentifier, domain, true),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction(resourceAddress, plancheck.ResourceActionUpdate),
+					},
+				},
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						resourceAddress,
+						tfjsonpath.New("proxy_endpoints"),
+						knownvalue.SetExact([]knownvalue.Check{
+							knownvalue.ObjectPartial(map[string]knownvalue.Check{
+								"proxy_protocol": knownvalue.Bool(true),
+							}),
+						}),
+					),
+				},
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccPiResourceProxyEndpointsConfig(identifier string, domain string, proxyProtocol bool) string {
 	return fmt.Sprintf(`
 resource "mythicbeasts_pi" %[1]q {
   identifier   = %[1]q
@@ -113,6 +255,75 @@ resource "mythicbeasts_pi" %[1]q {
   ssh_key      = %[2]q
   wait_for_dns = true
   memory       = 4096
+
+  proxy_endpoints = [
+    {
+      domain         = %[3]q
+      hostname       = %[1]q
+      proxy_protocol = %[4]t
+    },
+  ]
+}
+`, identifier, piSSHKey, domain, proxyProtocol)
+}
+
+func TestAccPiResourceSSHKeys(t *testing.T) {
+	piIdentifier := testAccIdentifier("tfpk", 20)
+	resourceAddress := "mythicbeasts_pi." + piIdentifier
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create with a repeated key, which is only installed once
+			{
+				Config: testAccPiResourceSSHKeysConfig(piIdentifier, piSSHKey, piSSHKey),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						resourceAddress,
+						tfjsonpath.New("ssh_key_fingerprints"),
+						knownvalue.ListSizeExact(1),
+					),
+				},
+			},
+			// Add a key in place
+			{
+				Config: testAccPiResourceSSHKeysConfig(piIdentifier, piSSHKey, piSSHKeyUpdated),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction(resourceAddress, plancheck.ResourceActionUpdate),
+					},
+				},
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						resourceAddress,
+						tfjsonpath.New("ssh_key_fingerprints"),
+						knownvalue.ListSizeExact(2),
+					),
+				},
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccPiResourceSSHKeysConfig(identifier string, sshKeys ...string) string {
+	keys := ""
+	for _, key := range sshKeys {
+		keys += fmt.Sprintf("    %q,\n", key)
+	}
+
+	return fmt.Sprintf(`
+resource "mythicbeasts_pi" %[1]q {
+  identifier   = %[1]q
+  disk_size    = 10
+  model        = 4
+  os_image     = "rpi-bookworm-arm64"
+  wait_for_dns = true
+  memory       = 4096
+
+  ssh_keys = [
+%[2]s  ]
 }
-`, identifier, sshKey)
+`, identifier, keys)
 }
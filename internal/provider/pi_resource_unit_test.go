@@ -0,0 +1,90 @@
+// Copyright IBM Corp. 2021, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestRequiresReplaceUnlessReimage(t *testing.T) {
+	ctx := context.Background()
+
+	schemaResp := &fwresource.SchemaResponse{}
+	(&PiResource{}).Schema(ctx, fwresource.SchemaRequest{}, schemaResp)
+	if schemaResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected schema diagnostics: %v", schemaResp.Diagnostics)
+	}
+
+	cases := []struct {
+		name    string
+		reimage *bool
+		want    bool
+	}{
+		{name: "true", reimage: boolPointer(true), want: false},
+		{name: "false", reimage: boolPointer(false), want: true},
+		{name: "null", reimage: nil, want: true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			config := testPiResourceConfig(t, schemaResp, "rpi-trixie-arm64", c.reimage)
+
+			req := planmodifier.StringRequest{
+				Path:        path.Root("os_image"),
+				Config:      config,
+				ConfigValue: types.StringValue("rpi-trixie-arm64"),
+				StateValue:  types.StringValue("rpi-bookworm-arm64"),
+				PlanValue:   types.StringValue("rpi-trixie-arm64"),
+			}
+			resp := &stringplanmodifier.RequiresReplaceIfFuncResponse{}
+
+			requiresReplaceUnlessReimage(ctx, req, resp)
+
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+			}
+			if resp.RequiresReplace != c.want {
+				t.Errorf("RequiresReplace = %t, want %t", resp.RequiresReplace, c.want)
+			}
+		})
+	}
+}
+
+func boolPointer(value bool) *bool {
+	return &value
+}
+
+// testPiResourceConfig builds a Pi config with only os_image and
+// reimage_on_change set.
+func testPiResourceConfig(t *testing.T, schemaResp *fwresource.SchemaResponse, osImage string, reimage *bool) tfsdk.Config {
+	t.Helper()
+
+	objectType, ok := schemaResp.Schema.Type().TerraformType(context.Background()).(tftypes.Object)
+	if !ok {
+		t.Fatalf("expected the Pi schema to be an object")
+	}
+
+	values := make(map[string]tftypes.Value, len(objectType.AttributeTypes))
+	for name, attributeType := range objectType.AttributeTypes {
+		values[name] = tftypes.NewValue(attributeType, nil)
+	}
+	values["os_image"] = tftypes.NewValue(tftypes.String, osImage)
+	if reimage != nil {
+		values["reimage_on_change"] = tftypes.NewValue(tftypes.Bool, *reimage)
+	}
+
+	return tfsdk.Config{
+		Schema: schemaResp.Schema,
+		Raw:    tftypes.NewValue(objectType, values),
+	}
+}
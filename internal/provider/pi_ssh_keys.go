@@ -0,0 +1,149 @@
+// Copyright IBM Corp. 2021, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"golang.org/x/crypto/ssh"
+)
+
+// sshPublicKey is a parsed authorized_keys entry.
+type sshPublicKey struct {
+	Line        string
+	Fingerprint string
+}
+
+// parseSSHPublicKey parses a single authorized_keys entry, keeping its
+// options and comment.
+func parseSSHPublicKey(line string) (sshPublicKey, error) {
+	line = strings.TrimSpace(line)
+	if line == "" {
+		return sshPublicKey{}, fmt.Errorf("key is empty")
+	}
+
+	key, _, _, rest, err := ssh.ParseAuthorizedKey([]byte(line))
+	if err != nil {
+		return sshPublicKey{}, err
+	}
+	if len(strings.TrimSpace(string(rest))) > 0 {
+		return sshPublicKey{}, fmt.Errorf("expected a single key")
+	}
+
+	return sshPublicKey{Line: line, Fingerprint: ssh.FingerprintSHA256(key)}, nil
+}
+
+// authorizedKeys parses the keys, drops any whose key is repeated and
+// returns the authorized_keys payload and the fingerprints of the keys in
+// it, in order.
+func authorizedKeys(lines []string) (string, []string, error) {
+	var kept []string
+	var fingerprints []string
+	seen := map[string]bool{}
+
+	for i, line := range lines {
+		key, err := parseSSHPublicKey(line)
+		if err != nil {
+			return "", nil, fmt.Errorf("key %d: %w", i, err)
+		}
+
+		if seen[key.Fingerprint] {
+			continue
+		}
+		seen[key.Fingerprint] = true
+
+		kept = append(kept, key.Line)
+		fingerprints = append(fingerprints, key.Fingerprint)
+	}
+
+	return strings.Join(kept, "\n") + "\n", fingerprints, nil
+}
+
+// sshKeyFingerprints returns the fingerprints of the keys in a free-form
+// ssh_key value, skipping lines that are not keys.
+func sshKeyFingerprints(value string) []string {
+	fingerprints := []string{}
+
+	for _, line := range strings.Split(value, "\n") {
+		key, err := parseSSHPublicKey(line)
+		if err != nil {
+			continue
+		}
+
+		fingerprints = append(fingerprints, key.Fingerprint)
+	}
+
+	return fingerprints
+}
+
+// piSSHKeys returns the authorized_keys payload to send for the Pi, from
+// ssh_keys or ssh_key, and the fingerprints of the keys in it. ok is false
+// when neither is configured.
+func piSSHKeys(ctx context.Context, config PiResourceModel) (payload string, fingerprints types.List, ok bool, diags diag.Diagnostics) {
+	fingerprints = types.ListValueMust(types.StringType, []attr.Value{})
+
+	if !config.SSHKeys.IsNull() && !config.SSHKeys.IsUnknown() {
+		var lines []string
+		diags.Append(config.SSHKeys.ElementsAs(ctx, &lines, false)...)
+		if diags.HasError() {
+			return "", fingerprints, false, diags
+		}
+
+		payload, prints, err := authorizedKeys(lines)
+		if err != nil {
+			diags.AddAttributeError(path.Root("ssh_keys"), "Invalid SSH key", err.Error())
+			return "", fingerprints, false, diags
+		}
+
+		fingerprints, d := types.ListValueFrom(ctx, types.StringType, prints)
+		diags.Append(d...)
+
+		return payload, fingerprints, true, diags
+	}
+
+	if !config.SSHKey.IsNull() && !config.SSHKey.IsUnknown() {
+		fingerprints, d := types.ListValueFrom(ctx, types.StringType, sshKeyFingerprints(config.SSHKey.ValueString()))
+		diags.Append(d...)
+
+		return config.SSHKey.ValueString(), fingerprints, true, diags
+	}
+
+	return "", fingerprints, false, diags
+}
+
+// modifyPlanSSHKeys plans the fingerprints of the keys that will be
+// installed. They only change on create, when ssh_keys changes or when
+// ssh_key_version changes.
+func (r *PiResource) modifyPlanSSHKeys(ctx context.Context, config PiResourceModel, plan PiResourceModel, state *PiResourceModel, resp *resource.ModifyPlanResponse) {
+	fingerprints := path.Root("ssh_key_fingerprints")
+
+	if config.SSHKeys.IsUnknown() || config.SSHKey.IsUnknown() {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, fingerprints, types.ListUnknown(types.StringType))...)
+		return
+	}
+
+	if state != nil && plan.SSHKeys.Equal(state.SSHKeys) && plan.SSHKeyVersion.Equal(state.SSHKeyVersion) {
+		return
+	}
+
+	_, planned, ok, diags := piSSHKeys(ctx, config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Without keys to send the installed keys are left as they are.
+	if !ok && state != nil {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, fingerprints, planned)...)
+}
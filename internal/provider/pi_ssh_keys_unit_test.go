@@ -0,0 +1,72 @@
+// Copyright IBM Corp. 2021, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"crypto/ed25519"
+	"strings"
+	"testing"
+
+	"golang.org/x/crypto/ssh"
+)
+
+func testSSHPublicKey(t *testing.T, comment string) (string, string) {
+	t.Helper()
+
+	public, _, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatalf("unable to generate key: %s", err)
+	}
+
+	key, err := ssh.NewPublicKey(public)
+	if err != nil {
+		t.Fatalf("unable to convert key: %s", err)
+	}
+
+	line := strings.TrimSpace(string(ssh.MarshalAuthorizedKey(key))) + " " + comment
+
+	return line, ssh.FingerprintSHA256(key)
+}
+
+func TestAuthorizedKeysRemovesDuplicates(t *testing.T) {
+	alice, aliceFingerprint := testSSHPublicKey(t, "alice@example.com")
+	bob, bobFingerprint := testSSHPublicKey(t, "bob@example.com")
+
+	// The same key with a different comment is still a duplicate.
+	aliceLaptop := strings.Replace(alice, "alice@example.com", "alice@laptop", 1)
+
+	payload, fingerprints, err := authorizedKeys([]string{alice, " " + bob + "\n", aliceLaptop})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if want := alice + "\n" + bob + "\n"; payload != want {
+		t.Fatalf("expected payload %q, got %q", want, payload)
+	}
+
+	if len(fingerprints) != 2 || fingerprints[0] != aliceFingerprint || fingerprints[1] != bobFingerprint {
+		t.Fatalf("expected fingerprints [%s %s], got %v", aliceFingerprint, bobFingerprint, fingerprints)
+	}
+}
+
+func TestAuthorizedKeysRejectsInvalidKey(t *testing.T) {
+	alice, _ := testSSHPublicKey(t, "alice@example.com")
+
+	if _, _, err := authorizedKeys([]string{alice, "ssh-ed25519 not-a-key"}); err == nil {
+		t.Fatal("expected an error for an invalid key")
+	}
+
+	if _, err := parseSSHPublicKey(alice + "\n" + alice); err == nil {
+		t.Fatal("expected an error for more than one key in an entry")
+	}
+}
+
+func TestSSHKeyFingerprintsSkipsOtherLines(t *testing.T) {
+	alice, aliceFingerprint := testSSHPublicKey(t, "alice@example.com")
+
+	fingerprints := sshKeyFingerprints("# on-call\n" + alice + "\n\n")
+	if len(fingerprints) != 1 || fingerprints[0] != aliceFingerprint {
+		t.Fatalf("expected [%s], got %v", aliceFingerprint, fingerprints)
+	}
+}
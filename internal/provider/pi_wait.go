@@ -0,0 +1,168 @@
+// Copyright IBM Corp. 2021, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/paultibbetts/mythicbeasts-client-go"
+	mbPi "github.com/paultibbetts/mythicbeasts-client-go/pi"
+)
+
+const (
+	defaultPiCreateTimeout = 30 * time.Minute
+	defaultPiDeleteTimeout = 10 * time.Minute
+	piWaitPollInterval     = 10 * time.Second
+	piStatusReady          = "ready"
+	piPowerTimeout         = 5 * time.Minute
+	piPowerOn              = "on"
+	piPowerOff             = "off"
+	piPowerDown            = "down"
+	piStatusRebooting      = "rebooting"
+	piStatusDeleting       = "deleting"
+	piStatusDeleted        = "deleted"
+	piRebootPollInterval   = 2 * time.Second
+	piRebootSettle         = 30 * time.Second
+)
+
+// waitForPiProvisioning polls the provisioning status of a Pi until it is
+// ready, provisioning fails or the context is cancelled, logging each
+// change of status as progress.
+func waitForPiProvisioning(ctx context.Context, client *mythicbeasts.Client, identifier string, interval time.Duration) error {
+	started := time.Now()
+	last := ""
+
+	getStatus := func(ctx context.Context) (string, error) {
+		status, err := client.Pi().GetProvisioningStatus(ctx, identifier)
+		if err != nil {
+			return "", err
+		}
+
+		if status.Error != "" {
+			return "", fmt.Errorf("provisioning failed: %s", status.Error)
+		}
+
+		current := status.Status
+		if status.Ready {
+			current = piStatusReady
+		}
+
+		if current != last {
+			tflog.Info(ctx, fmt.Sprintf("Pi %s is %s", identifier, current), map[string]interface{}{
+				"elapsed": time.Since(started).Round(time.Second).String(),
+			})
+			last = current
+		}
+
+		return current, nil
+	}
+
+	return waitForStatus(ctx, getStatus, piStatusReady, interval)
+}
+
+// waitForPiDeleted polls a Pi until the API no longer finds it or the
+// context is cancelled, logging progress while it is still present.
+func waitForPiDeleted(ctx context.Context, identifier string, getServer func(context.Context) (mbPi.Server, error), interval time.Duration) error {
+	started := time.Now()
+
+	getStatus := func(ctx context.Context) (string, error) {
+		_, err := getServer(ctx)
+		if err != nil {
+			if piNotFound(err) {
+				return piStatusDeleted, nil
+			}
+
+			return "", err
+		}
+
+		tflog.Info(ctx, fmt.Sprintf("Pi %s is still being deleted", identifier), map[string]interface{}{
+			"elapsed": time.Since(started).Round(time.Second).String(),
+		})
+
+		return piStatusDeleting, nil
+	}
+
+	return waitForStatus(ctx, getStatus, piStatusDeleted, interval)
+}
+
+// piNotFound reports whether err is the API saying the Pi doesn't exist.
+// The client has no typed error for it, so the status is matched in the
+// message.
+func piNotFound(err error) bool {
+	message := strings.ToLower(err.Error())
+
+	return strings.Contains(message, "404") || strings.Contains(message, "not found")
+}
+
+// piPowerState returns the power_state value for a server.
+func piPowerState(server mbPi.Server) string {
+	if server.Power {
+		return piPowerOn
+	}
+
+	return piPowerOff
+}
+
+// waitForPiPower polls a Pi until its power state is the wanted one or
+// the context is cancelled.
+func waitForPiPower(ctx context.Context, client *mythicbeasts.Client, identifier string, want string, interval time.Duration) (mbPi.Server, error) {
+	var server mbPi.Server
+
+	getStatus := func(ctx context.Context) (string, error) {
+		var err error
+		server, err = client.Pi().Get(ctx, identifier)
+		if err != nil {
+			return "", err
+		}
+
+		return piPowerState(server), nil
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("waiting for Pi %s to be powered %s", identifier, want))
+
+	return server, waitForStatus(ctx, getStatus, want, interval)
+}
+
+// waitForPiReboot polls a Pi after a successful reboot request until it is
+// powered on again, or the context is cancelled. A Pi that goes down, by
+// being powered off or unavailable, has rebooted once it is back on. The
+// API doesn't report uptime, and a quick or soft reboot may never read as
+// down, so a Pi that stays powered on for the settle period has also
+// rebooted. It reports whether the Pi was seen going down.
+func waitForPiReboot(ctx context.Context, getServer func(context.Context) (mbPi.Server, error), interval time.Duration, settle time.Duration) (mbPi.Server, bool, error) {
+	var server mbPi.Server
+	settled := time.Now().Add(settle)
+	wentDown := false
+
+	getStatus := func(ctx context.Context) (string, error) {
+		current, err := getServer(ctx)
+		if err != nil {
+			tflog.Debug(ctx, "Pi is unavailable", map[string]interface{}{"error": err.Error()})
+			wentDown = true
+			return piPowerDown, nil
+		}
+
+		server = current
+		if !current.Power {
+			wentDown = true
+			return piPowerDown, nil
+		}
+
+		if !wentDown && time.Now().Before(settled) {
+			return piStatusRebooting, nil
+		}
+
+		return piPowerOn, nil
+	}
+
+	if err := waitForStatus(ctx, getStatus, piPowerOn, interval); err != nil {
+		return server, wentDown, fmt.Errorf("waiting for the Pi to come back: %w", err)
+	}
+
+	return server, wentDown, nil
+}
@@ -0,0 +1,124 @@
+// Copyright IBM Corp. 2021, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	mbPi "github.com/paultibbetts/mythicbeasts-client-go/pi"
+)
+
+func TestWaitForPiRebootWaitsForDownThenUp(t *testing.T) {
+	type result struct {
+		power bool
+		err   error
+	}
+	results := []result{
+		{power: true},
+		{power: true},
+		{err: errors.New("server unavailable")},
+		{power: false},
+		{power: true},
+	}
+	calls := 0
+
+	getServer := func(_ context.Context) (mbPi.Server, error) {
+		r := results[calls]
+		calls++
+		return mbPi.Server{Power: r.power}, r.err
+	}
+
+	server, wentDown, err := waitForPiReboot(context.Background(), getServer, time.Millisecond, time.Hour)
+	if err != nil {
+		t.Fatalf("expected no error, got %s", err)
+	}
+
+	if !server.Power || !wentDown {
+		t.Fatalf("expected the Pi to go down and be powered on, got power %t and went down %t", server.Power, wentDown)
+	}
+
+	if calls != len(results) {
+		t.Fatalf("expected %d reads, got %d", len(results), calls)
+	}
+}
+
+func TestWaitForPiRebootAcceptsAPiThatStaysOn(t *testing.T) {
+	getServer := func(_ context.Context) (mbPi.Server, error) {
+		return mbPi.Server{Power: true}, nil
+	}
+
+	server, wentDown, err := waitForPiReboot(context.Background(), getServer, time.Millisecond, 5*time.Millisecond)
+	if err != nil {
+		t.Fatalf("expected no error, got %s", err)
+	}
+
+	if !server.Power || wentDown {
+		t.Fatalf("expected the Pi to stay powered on, got power %t and went down %t", server.Power, wentDown)
+	}
+}
+
+func TestWaitForPiRebootTimesOutWhenNeverBack(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	getServer := func(_ context.Context) (mbPi.Server, error) {
+		return mbPi.Server{Power: false}, nil
+	}
+
+	_, _, err := waitForPiReboot(ctx, getServer, time.Millisecond, time.Millisecond)
+	if err == nil || !strings.Contains(err.Error(), "waiting for the Pi to come back") {
+		t.Fatalf("expected a timeout waiting for the Pi to come back, got %v", err)
+	}
+}
+
+func TestPiNotFound(t *testing.T) {
+	cases := []struct {
+		err  error
+		want bool
+	}{
+		{err: errors.New("unexpected status 404: server does not exist"), want: true},
+		{err: errors.New("Pi not found"), want: true},
+		{err: errors.New("unexpected status 500: internal server error"), want: false},
+	}
+
+	for _, c := range cases {
+		if got := piNotFound(c.err); got != c.want {
+			t.Errorf("piNotFound(%q) = %t, want %t", c.err, got, c.want)
+		}
+	}
+}
+
+func TestWaitForPiDeleted(t *testing.T) {
+	results := []error{nil, nil, errors.New("unexpected status 404: not found")}
+	calls := 0
+
+	getServer := func(_ context.Context) (mbPi.Server, error) {
+		err := results[calls]
+		calls++
+		return mbPi.Server{}, err
+	}
+
+	if err := waitForPiDeleted(context.Background(), "test", getServer, time.Millisecond); err != nil {
+		t.Fatalf("expected no error, got %s", err)
+	}
+
+	if calls != len(results) {
+		t.Fatalf("expected %d reads, got %d", len(results), calls)
+	}
+}
+
+func TestWaitForPiDeletedReturnsOtherErrors(t *testing.T) {
+	getServer := func(_ context.Context) (mbPi.Server, error) {
+		return mbPi.Server{}, errors.New("unexpected status 500: internal server error")
+	}
+
+	err := waitForPiDeleted(context.Background(), "test", getServer, time.Millisecond)
+	if err == nil || !strings.Contains(err.Error(), "internal server error") {
+		t.Fatalf("expected the read error, got %v", err)
+	}
+}
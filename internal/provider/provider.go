@@ -7,7 +7,9 @@ import (
 	"context"
 	"os"
 
+	"github.com/hashicorp/terraform-plugin-framework/action"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
@@ -18,7 +20,9 @@ import (
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ provider.Provider = &mythicbeastsProvider{}
+	_ provider.Provider                       = &mythicbeastsProvider{}
+	_ provider.ProviderWithEphemeralResources = &mythicbeastsProvider{}
+	_ provider.ProviderWithActions            = &mythicbeastsProvider{}
 )
 
 // New is a helper function to simplify provider server and testing implementation.
@@ -173,27 +177,50 @@ func (p *mythicbeastsProvider) Configure(ctx context.Context, req provider.Confi
 	// type Configure methods.
 	resp.DataSourceData = client
 	resp.ResourceData = client
+	resp.EphemeralResourceData = client
+	resp.ActionData = client
+}
+
+// Actions defines the actions implemented in the provider.
+func (p *mythicbeastsProvider) Actions(_ context.Context) []func() action.Action {
+	return []func() action.Action{
+		NewPiRebootAction,
+		NewVPSPowerAction,
+	}
 }
 
 // DataSources defines the data sources implemented in the provider.
 func (p *mythicbeastsProvider) DataSources(_ context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
+		NewPiDataSource,
 		NewPiModelsDataSource,
 		NewPiOperatingSystemsDataSource,
+		NewProxyEndpointsDataSource,
+		NewVPSDataSource,
 		NewVPSDiskSizesDataSource,
 		NewVPSHostsDataSource,
 		NewVPSImagesDataSource,
+		NewVPSISOImagesDataSource,
 		NewVPSPricingDataSource,
 		NewVPSProductsDataSource,
 		NewVPSZonesDataSource,
 	}
 }
 
+// EphemeralResources defines the ephemeral resources implemented in the provider.
+func (p *mythicbeastsProvider) EphemeralResources(_ context.Context) []func() ephemeral.EphemeralResource {
+	return []func() ephemeral.EphemeralResource{
+		NewVPSConsoleEphemeralResource,
+	}
+}
+
 // Resources defines the resources implemented in the provider.
 func (p *mythicbeastsProvider) Resources(_ context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
+		NewPiFleetResource,
 		NewPiResource,
 		NewProxyEndpointResource,
+		NewProxyEndpointSetResource,
 		NewUserDataResource,
 		NewVPSResource,
 	}
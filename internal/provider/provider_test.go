@@ -4,11 +4,14 @@
 package provider
 
 import (
+	"context"
 	"os"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-testing/echoprovider"
+	"github.com/paultibbetts/mythicbeasts-client-go"
 )
 
 // testAccProtoV6ProviderFactories is used to instantiate a provider during acceptance testing.
@@ -18,6 +21,13 @@ var testAccProtoV6ProviderFactories = map[string]func() (tfprotov6.ProviderServe
 	"mythicbeasts": providerserver.NewProtocol6WithError(New("test")()),
 }
 
+// testAccProtoV6ProviderFactoriesWithEcho includes the echo provider alongside
+// the mythicbeasts provider so that ephemeral values can be checked in state.
+var testAccProtoV6ProviderFactoriesWithEcho = map[string]func() (tfprotov6.ProviderServer, error){
+	"mythicbeasts": providerserver.NewProtocol6WithError(New("test")()),
+	"echo":         echoprovider.NewProviderServer(),
+}
+
 func testAccPreCheck(t *testing.T) {
 	t.Helper()
 
@@ -33,3 +43,28 @@ func testAccPreCheck(t *testing.T) {
 		t.Fatal("MYTHICBEASTS_SECRET must be set for acceptance tests")
 	}
 }
+
+// testAccPiModelNumbers returns the Pi models reported by the API, so that
+// tests cover new models without changes.
+func testAccPiModelNumbers(t *testing.T) []int64 {
+	t.Helper()
+
+	testAccPreCheck(t)
+
+	client, err := mythicbeasts.NewClient(os.Getenv("MYTHICBEASTS_KEYID"), os.Getenv("MYTHICBEASTS_SECRET"))
+	if err != nil {
+		t.Fatalf("unable to create client: %s", err)
+	}
+
+	models, err := client.Pi().ListModels(context.Background())
+	if err != nil {
+		t.Fatalf("unable to list Pi models: %s", err)
+	}
+
+	numbers := piModelNumbers(models)
+	if len(numbers) == 0 {
+		t.Fatal("expected the API to report at least one Pi model")
+	}
+
+	return numbers
+}
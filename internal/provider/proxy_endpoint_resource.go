@@ -5,11 +5,17 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"regexp"
+	"slices"
+	"sort"
 	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -18,6 +24,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/paultibbetts/mythicbeasts-client-go"
 	mbProxy "github.com/paultibbetts/mythicbeasts-client-go/proxy"
 )
@@ -27,8 +34,14 @@ var (
 	_ resource.Resource                = &ProxyEndpointResource{}
 	_ resource.ResourceWithConfigure   = &ProxyEndpointResource{}
 	_ resource.ResourceWithImportState = &ProxyEndpointResource{}
+	_ resource.ResourceWithModifyPlan  = &ProxyEndpointResource{}
 )
 
+// proxyEndpointImportedKey marks private state of an imported endpoint whose
+// sites aren't configured yet, so its imported sites are kept instead of
+// defaulting to all.
+const proxyEndpointImportedKey = "imported"
+
 // NewProxyEndpointResource is a helper function to simplify the provider implementation.
 func NewProxyEndpointResource() resource.Resource {
 	return &ProxyEndpointResource{}
@@ -46,6 +59,7 @@ type ProxyEndpointResourceModel struct {
 	Hostname      types.String `tfsdk:"hostname"`
 	Address       types.String `tfsdk:"address"`
 	Site          types.String `tfsdk:"site"`
+	Sites         types.Set    `tfsdk:"sites"`
 	ProxyProtocol types.Bool   `tfsdk:"proxy_protocol"`
 }
 
@@ -58,14 +72,12 @@ func (r *ProxyEndpointResource) Metadata(_ context.Context, req resource.Metadat
 func (r *ProxyEndpointResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		MarkdownDescription: "Manages endpoints for the IPv4 to IPv6 proxy.\n\n" +
-			"Can be used to make a [`mythicbeasts_pi` resource](../resources/pi) available via IPv4.",
+			"Can be used to make a [`mythicbeasts_pi` resource](../resources/pi) available via IPv4.\n\n" +
+			"One resource publishes the address in one or more proxy sites; the sites can be changed in place.",
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Computed:            true,
-				MarkdownDescription: "Composite identifier for the proxy endpoint (domain/hostname/address/site).",
-				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.UseStateForUnknown(),
-				},
+				MarkdownDescription: "Composite identifier for the proxy endpoint (domain/hostname/address/sites), with the sites in order and separated by commas.",
 			},
 			"domain": schema.StringAttribute{
 				Required:            true,
@@ -95,25 +107,36 @@ func (r *ProxyEndpointResource) Schema(_ context.Context, _ resource.SchemaReque
 			},
 			"address": schema.StringAttribute{
 				Required:            true,
-				MarkdownDescription: "IPv6 address of the server to which requests are proxied.",
+				MarkdownDescription: "IPv6 address of the server to which requests are proxied.\n\nChanging the address updates the endpoint in place without dropping traffic: the endpoint for the new address is created and confirmed before the one for the old address is deleted, and the change is rolled back if either step fails.",
 				PlanModifiers: []planmodifier.String{
 					IPv6Normalize(),
-					stringplanmodifier.RequiresReplace(),
 				},
 			},
 			"site": schema.StringAttribute{
 				Optional:            true,
 				Computed:            true,
-				MarkdownDescription: "Site in which the proxy server is located, or `all` for all sites.",
+				MarkdownDescription: "Site in which the proxy server is located, or `all` for all sites. Use `sites` for more than one site; when it lists several sites this is null.\nDefault: `all`",
 				Validators: []validator.String{
 					stringvalidator.RegexMatches(
-						regexp.MustCompile(`^\S+$`),
-						"must not be empty or contain whitespace",
+						regexp.MustCompile(`^[^\s,/]+$`),
+						"must not be empty or contain whitespace, commas or slashes",
 					),
+					stringvalidator.ConflictsWith(path.MatchRoot("sites")),
 				},
-				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
-					stringplanmodifier.UseStateForUnknown(),
+			},
+			"sites": schema.SetAttribute{
+				Optional:            true,
+				Computed:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Sites in which the proxy server is located. `all` publishes the endpoint in every site and can't be combined with other sites. An imported endpoint keeps its imported sites until `site` or `sites` is set.\nDefault: `[\"all\"]`",
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+					setvalidator.ValueStringsAre(
+						stringvalidator.RegexMatches(
+							regexp.MustCompile(`^[^\s,/]+$`),
+							"must not be empty or contain whitespace, commas or slashes",
+						),
+					),
 				},
 			},
 			"proxy_protocol": schema.BoolAttribute{
@@ -150,6 +173,280 @@ func (r *ProxyEndpointResource) Configure(_ context.Context, req resource.Config
 	r.client = client
 }
 
+// proxyEndpointID returns the composite identifier of the endpoints for an
+// address in the sites.
+func proxyEndpointID(domain, hostname, address string, sites []string) string {
+	return fmt.Sprintf("%s/%s/%s/%s", domain, hostname, address, strings.Join(sites, ","))
+}
+
+// sites returns the sites of the endpoints in order, falling back to site
+// for state written before sites existed.
+func (m ProxyEndpointResourceModel) sites(ctx context.Context) ([]string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	var sites []string
+
+	switch {
+	case !m.Sites.IsNull() && !m.Sites.IsUnknown():
+		diags.Append(m.Sites.ElementsAs(ctx, &sites, false)...)
+	case !m.Site.IsNull() && !m.Site.IsUnknown():
+		sites = []string{m.Site.ValueString()}
+	}
+	sort.Strings(sites)
+
+	return sites, diags
+}
+
+// setSites records the sites of the endpoints, and the site when there is
+// only one.
+func (m *ProxyEndpointResourceModel) setSites(sites []string) {
+	values := make([]attr.Value, 0, len(sites))
+	for _, site := range sites {
+		values = append(values, types.StringValue(site))
+	}
+	m.Sites = types.SetValueMust(types.StringType, values)
+
+	m.Site = types.StringNull()
+	if len(sites) == 1 {
+		m.Site = types.StringValue(sites[0])
+	}
+}
+
+// ModifyPlan resolves site and sites to the planned sites, and plans the
+// identifier that follows from them.
+func (r *ProxyEndpointResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to do on destroy.
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var config ProxyEndpointResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var plan ProxyEndpointResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if config.Sites.IsUnknown() || config.Site.IsUnknown() {
+		plan.Site = types.StringUnknown()
+		plan.Sites = types.SetUnknown(types.StringType)
+		plan.ID = types.StringUnknown()
+		resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+		return
+	}
+
+	sites, diags := config.sites(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	imported, diags := req.Private.GetKey(ctx, proxyEndpointImportedKey)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if len(sites) > 0 && imported != nil {
+		// The configuration manages the sites from now on.
+		resp.Diagnostics.Append(resp.Private.SetKey(ctx, proxyEndpointImportedKey, nil)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	if len(sites) == 0 && imported != nil && !req.State.Raw.IsNull() {
+		// Keep the sites of an imported endpoint that doesn't configure them.
+		var state ProxyEndpointResourceModel
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		sites, diags = state.sites(ctx)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	if len(sites) == 0 {
+		sites = []string{"all"}
+	}
+
+	if len(sites) > 1 && slices.Contains(sites, "all") {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("sites"),
+			"Invalid sites",
+			"The site \"all\" already includes every site, so it can't be combined with other sites.",
+		)
+		return
+	}
+
+	plan.setSites(sites)
+
+	plan.ID = types.StringUnknown()
+	if !plan.Domain.IsUnknown() && !plan.Hostname.IsUnknown() && !plan.Address.IsUnknown() {
+		plan.ID = types.StringValue(proxyEndpointID(plan.Domain.ValueString(), plan.Hostname.ValueString(), plan.Address.ValueString(), sites))
+	}
+
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+}
+
+// proxyEndpointAPI is the part of the proxy API used to manage endpoints.
+type proxyEndpointAPI interface {
+	CreateOrUpdateEndpoints(ctx context.Context, domain, hostname, address, site string, endpoints []mbProxy.EndpointRequest) ([]mbProxy.Endpoint, error)
+	GetEndpoint(ctx context.Context, domain, hostname, address, site string) (mbProxy.Endpoint, bool, error)
+	DeleteEndpoints(ctx context.Context, domain, hostname, address, site string) error
+}
+
+// proxyEndpointTarget is an address published in a set of sites.
+type proxyEndpointTarget struct {
+	Address       string
+	Sites         []string
+	ProxyProtocol bool
+}
+
+// putProxyEndpoints creates or updates the endpoints for the address in
+// each site and returns them in the order of the sites. On error it
+// returns the endpoints created so far.
+func putProxyEndpoints(ctx context.Context, api proxyEndpointAPI, domain, hostname string, target proxyEndpointTarget) ([]mbProxy.Endpoint, error) {
+	address := target.Address
+	proxyProtocol := target.ProxyProtocol
+	result := make([]mbProxy.Endpoint, 0, len(target.Sites))
+
+	for _, site := range target.Sites {
+		endpoints, err := api.CreateOrUpdateEndpoints(
+			ctx,
+			domain,
+			hostname,
+			address,
+			site,
+			[]mbProxy.EndpointRequest{{Site: site, ProxyProtocol: proxyProtocol}},
+		)
+		if err != nil {
+			return result, fmt.Errorf("site %s: %w", site, err)
+		}
+
+		found := false
+		for _, endpoint := range endpoints {
+			if endpoint.Site == site {
+				result = append(result, endpoint)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return result, fmt.Errorf("site %s: expected an endpoint for the site, got %d endpoints for other sites", site, len(endpoints))
+		}
+	}
+
+	return result, nil
+}
+
+// deleteProxyEndpointSites removes the endpoints for the address in each
+// site.
+func deleteProxyEndpointSites(ctx context.Context, api proxyEndpointAPI, domain, hostname, address string, sites []string) error {
+	for _, site := range sites {
+		if err := api.DeleteEndpoints(ctx, domain, hostname, address, site); err != nil {
+			return fmt.Errorf("site %s: %w", site, err)
+		}
+	}
+
+	return nil
+}
+
+// confirmProxyEndpoints checks that the endpoints for the target exist in
+// every site with the wanted settings.
+func confirmProxyEndpoints(ctx context.Context, api proxyEndpointAPI, domain, hostname string, target proxyEndpointTarget) error {
+	for _, site := range target.Sites {
+		endpoint, found, err := api.GetEndpoint(ctx, domain, hostname, target.Address, site)
+		if err != nil {
+			return fmt.Errorf("site %s: %w", site, err)
+		}
+		if !found {
+			return fmt.Errorf("site %s: endpoint not found after creating it", site)
+		}
+		if endpoint.ProxyProtocol != target.ProxyProtocol {
+			return fmt.Errorf("site %s: expected proxy_protocol %t, got %t", site, target.ProxyProtocol, endpoint.ProxyProtocol)
+		}
+	}
+
+	return nil
+}
+
+// moveProxyEndpoints points the endpoints for a hostname at a new address
+// without a gap in service: the endpoints for the new address are created
+// and confirmed before those for the old address are deleted. If either
+// step fails, the old endpoints are restored and the new ones removed.
+func moveProxyEndpoints(ctx context.Context, api proxyEndpointAPI, domain, hostname string, from, to proxyEndpointTarget) ([]mbProxy.Endpoint, error) {
+	tflog.Info(ctx, fmt.Sprintf("moving proxy endpoint %s.%s from %s to %s", hostname, domain, from.Address, to.Address))
+
+	endpoints, err := putProxyEndpoints(ctx, api, domain, hostname, to)
+	if err == nil {
+		err = confirmProxyEndpoints(ctx, api, domain, hostname, to)
+	}
+	if err != nil {
+		err = fmt.Errorf("creating the endpoints for %s: %w", to.Address, err)
+		return nil, rollBackProxyEndpointMove(ctx, api, domain, hostname, from, endpoints, false, err)
+	}
+
+	if err := deleteProxyEndpointSites(ctx, api, domain, hostname, from.Address, from.Sites); err != nil {
+		err = fmt.Errorf("deleting the endpoints for %s: %w", from.Address, err)
+		return nil, rollBackProxyEndpointMove(ctx, api, domain, hostname, from, endpoints, true, err)
+	}
+
+	return endpoints, nil
+}
+
+// rollBackProxyEndpointMove undoes a failed move by restoring the old
+// endpoints, when some may have been deleted, and removing the created
+// ones. It returns cause, along with any error rolling back.
+func rollBackProxyEndpointMove(ctx context.Context, api proxyEndpointAPI, domain, hostname string, from proxyEndpointTarget, created []mbProxy.Endpoint, restore bool, cause error) error {
+	tflog.Warn(ctx, fmt.Sprintf("rolling back move of proxy endpoint %s.%s: %s", hostname, domain, cause.Error()))
+
+	errs := []error{cause}
+
+	if restore {
+		if _, err := putProxyEndpoints(ctx, api, domain, hostname, from); err != nil {
+			errs = append(errs, fmt.Errorf("restoring the endpoints for %s: %w", from.Address, err))
+		}
+	}
+
+	for _, endpoint := range created {
+		if err := api.DeleteEndpoints(ctx, domain, hostname, endpoint.Address.String(), endpoint.Site); err != nil {
+			errs = append(errs, fmt.Errorf("removing the endpoint for %s in site %s: %w", endpoint.Address.String(), endpoint.Site, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// proxyEndpointState returns the state for endpoints for the same domain,
+// hostname and address, in the order of their sites.
+func proxyEndpointState(endpoints []mbProxy.Endpoint) ProxyEndpointResourceModel {
+	sites := make([]string, 0, len(endpoints))
+	for _, endpoint := range endpoints {
+		sites = append(sites, endpoint.Site)
+	}
+
+	first := endpoints[0]
+	state := ProxyEndpointResourceModel{
+		ID:            types.StringValue(proxyEndpointID(first.Domain, first.Hostname, first.Address.String(), sites)),
+		Domain:        types.StringValue(first.Domain),
+		Hostname:      types.StringValue(first.Hostname),
+		Address:       types.StringValue(first.Address.String()),
+		ProxyProtocol: types.BoolValue(first.ProxyProtocol),
+	}
+	state.setSites(sites)
+
+	return state
+}
+
 // Create creates the resource and sets the initial Terraform state.
 func (r *ProxyEndpointResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	// Retrieve values from plan
@@ -177,11 +474,10 @@ func (r *ProxyEndpointResource) Create(ctx context.Context, req resource.CreateR
 	}
 	address = normalizedAddress
 
-	site := "all"
-	if !config.Site.IsNull() && !config.Site.IsUnknown() {
-		site = config.Site.ValueString()
-	} else if !plan.Site.IsNull() && !plan.Site.IsUnknown() {
-		site = plan.Site.ValueString()
+	sites, diags := plan.sites(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
 	proxyProtocol := false
@@ -191,44 +487,26 @@ func (r *ProxyEndpointResource) Create(ctx context.Context, req resource.CreateR
 		proxyProtocol = plan.ProxyProtocol.ValueBool()
 	}
 
-	endpointReq := mbProxy.EndpointRequest{
-		Site:          site,
-		ProxyProtocol: proxyProtocol,
-	}
-
-	endpoints, err := r.client.Proxy().CreateOrUpdateEndpoints(
-		ctx,
-		domain,
-		hostname,
-		address,
-		site,
-		[]mbProxy.EndpointRequest{endpointReq},
-	)
+	endpoints, err := putProxyEndpoints(ctx, r.client.Proxy(), domain, hostname, proxyEndpointTarget{Address: address, Sites: sites, ProxyProtocol: proxyProtocol})
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error creating Proxy Endpoint",
-			"Could not create Proxy Endpoint, unexpected error: "+err.Error(),
-		)
-		return
-	}
+		// Remove the endpoints that were created, so that a retry starts
+		// afresh, and report any that are left behind.
+		errs := []error{err}
+		for _, endpoint := range endpoints {
+			if deleteErr := r.client.Proxy().DeleteEndpoints(ctx, domain, hostname, address, endpoint.Site); deleteErr != nil {
+				errs = append(errs, fmt.Errorf("removing the endpoint for %s in site %s: %w", address, endpoint.Site, deleteErr))
+			}
+		}
+		err = errors.Join(errs...)
 
-	if len(endpoints) != 1 {
 		resp.Diagnostics.AddError(
 			"Error creating Proxy Endpoint",
-			fmt.Sprintf("Expected 1 endpoint, got %d", len(endpoints)),
+			"Could not create Proxy Endpoint, unexpected error: "+err.Error(),
 		)
 		return
 	}
 
-	endpoint := endpoints[0]
-	state := ProxyEndpointResourceModel{
-		ID:            types.StringValue(fmt.Sprintf("%s/%s/%s/%s", endpoint.Domain, endpoint.Hostname, endpoint.Address.String(), endpoint.Site)),
-		Domain:        types.StringValue(endpoint.Domain),
-		Hostname:      types.StringValue(endpoint.Hostname),
-		Address:       types.StringValue(endpoint.Address.String()),
-		Site:          types.StringValue(endpoint.Site),
-		ProxyProtocol: types.BoolValue(endpoint.ProxyProtocol),
-	}
+	state := proxyEndpointState(endpoints)
 
 	// Set state to fully populated data
 	diags = resp.State.Set(ctx, &state)
@@ -247,13 +525,19 @@ func (r *ProxyEndpointResource) Read(ctx context.Context, req resource.ReadReque
 		return
 	}
 
+	sites, diags := state.sites(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	if state.Domain.IsNull() || state.Domain.IsUnknown() ||
 		state.Hostname.IsNull() || state.Hostname.IsUnknown() ||
 		state.Address.IsNull() || state.Address.IsUnknown() ||
-		state.Site.IsNull() || state.Site.IsUnknown() {
+		len(sites) == 0 {
 		resp.Diagnostics.AddError(
 			"Missing proxy endpoint identity",
-			"Domain, hostname, address, and site must be set in state to read a proxy endpoint.",
+			"Domain, hostname, address, and sites must be set in state to read a proxy endpoint.",
 		)
 		return
 	}
@@ -261,7 +545,6 @@ func (r *ProxyEndpointResource) Read(ctx context.Context, req resource.ReadReque
 	domain := state.Domain.ValueString()
 	hostname := state.Hostname.ValueString()
 	address := state.Address.ValueString()
-	site := state.Site.ValueString()
 
 	normalizedAddress, err := normalizeIPv6(address)
 	if err != nil {
@@ -272,25 +555,28 @@ func (r *ProxyEndpointResource) Read(ctx context.Context, req resource.ReadReque
 		return
 	}
 
-	endpoint, found, err := r.client.Proxy().GetEndpoint(ctx, domain, hostname, normalizedAddress, site)
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error reading Proxy Endpoint",
-			fmt.Sprintf("Could not read Proxy Endpoint %s/%s/%s/%s: %s", domain, hostname, address, site, err.Error()),
-		)
-		return
+	// Sites whose endpoint was removed outside of Terraform are dropped,
+	// so that the next apply recreates them.
+	endpoints := make([]mbProxy.Endpoint, 0, len(sites))
+	for _, site := range sites {
+		endpoint, found, err := r.client.Proxy().GetEndpoint(ctx, domain, hostname, normalizedAddress, site)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error reading Proxy Endpoint",
+				fmt.Sprintf("Could not read Proxy Endpoint %s/%s/%s/%s: %s", domain, hostname, address, site, err.Error()),
+			)
+			return
+		}
+		if found {
+			endpoints = append(endpoints, endpoint)
+		}
 	}
-	if !found {
+	if len(endpoints) == 0 {
 		resp.State.RemoveResource(ctx)
 		return
 	}
 
-	state.ID = types.StringValue(fmt.Sprintf("%s/%s/%s/%s", endpoint.Domain, endpoint.Hostname, endpoint.Address.String(), endpoint.Site))
-	state.Domain = types.StringValue(endpoint.Domain)
-	state.Hostname = types.StringValue(endpoint.Hostname)
-	state.Address = types.StringValue(endpoint.Address.String())
-	state.Site = types.StringValue(endpoint.Site)
-	state.ProxyProtocol = types.BoolValue(endpoint.ProxyProtocol)
+	state = proxyEndpointState(endpoints)
 
 	diags = resp.State.Set(ctx, &state)
 	resp.Diagnostics.Append(diags...)
@@ -315,35 +601,33 @@ func (r *ProxyEndpointResource) Update(ctx context.Context, req resource.UpdateR
 		return
 	}
 
-	domain := plan.Domain
-	if domain.IsNull() || domain.IsUnknown() {
-		domain = state.Domain
-	}
-	hostname := plan.Hostname
-	if hostname.IsNull() || hostname.IsUnknown() {
-		hostname = state.Hostname
-	}
-	address := plan.Address
-	if address.IsNull() || address.IsUnknown() {
-		address = state.Address
+	planSites, diags := plan.sites(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
-	site := plan.Site
-	if site.IsNull() || site.IsUnknown() {
-		site = state.Site
+
+	stateSites, diags := state.sites(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	if domain.IsNull() || domain.IsUnknown() ||
-		hostname.IsNull() || hostname.IsUnknown() ||
-		address.IsNull() || address.IsUnknown() ||
-		site.IsNull() || site.IsUnknown() {
+	if plan.Domain.IsNull() || plan.Domain.IsUnknown() ||
+		plan.Hostname.IsNull() || plan.Hostname.IsUnknown() ||
+		plan.Address.IsNull() || plan.Address.IsUnknown() ||
+		len(planSites) == 0 {
 		resp.Diagnostics.AddError(
 			"Missing proxy endpoint identity",
-			"Domain, hostname, address, and site must be set in state to update a proxy endpoint.",
+			"Domain, hostname, address, and sites must be set to update a proxy endpoint.",
 		)
 		return
 	}
 
-	normalizedAddress, err := normalizeIPv6(address.ValueString())
+	domain := plan.Domain.ValueString()
+	hostname := plan.Hostname.ValueString()
+
+	normalizedAddress, err := normalizeIPv6(plan.Address.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddAttributeError(path.Root("address"), "Invalid IPv6 address", err.Error())
 		return
@@ -361,19 +645,39 @@ func (r *ProxyEndpointResource) Update(ctx context.Context, req resource.UpdateR
 		return
 	}
 
-	endpointReq := mbProxy.EndpointRequest{
-		Site:          site.ValueString(),
-		ProxyProtocol: proxyProtocol.ValueBool(),
+	oldAddress, err := normalizeIPv6(state.Address.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid IPv6 address in state",
+			fmt.Sprintf("Could not parse address %q: %s", state.Address.ValueString(), err.Error()),
+		)
+		return
 	}
 
-	endpoints, err := r.client.Proxy().CreateOrUpdateEndpoints(
-		ctx,
-		domain.ValueString(),
-		hostname.ValueString(),
-		normalizedAddress,
-		site.ValueString(),
-		[]mbProxy.EndpointRequest{endpointReq},
-	)
+	target := proxyEndpointTarget{Address: normalizedAddress, Sites: planSites, ProxyProtocol: proxyProtocol.ValueBool()}
+
+	if oldAddress != normalizedAddress {
+		from := proxyEndpointTarget{Address: oldAddress, Sites: stateSites, ProxyProtocol: state.ProxyProtocol.ValueBool()}
+
+		endpoints, err := moveProxyEndpoints(ctx, r.client.Proxy(), domain, hostname, from, target)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error updating Proxy Endpoint",
+				fmt.Sprintf("Could not move Proxy Endpoint from %s to %s: %s", oldAddress, normalizedAddress, err.Error()),
+			)
+
+			// Keep the prior state, as the move was rolled back.
+			resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+			return
+		}
+
+		state = proxyEndpointState(endpoints)
+		resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+		return
+	}
+
+	// Publish in the planned sites before withdrawing from the others.
+	endpoints, err := putProxyEndpoints(ctx, r.client.Proxy(), domain, hostname, target)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error updating Proxy Endpoint",
@@ -382,23 +686,22 @@ func (r *ProxyEndpointResource) Update(ctx context.Context, req resource.UpdateR
 		return
 	}
 
-	if len(endpoints) != 1 {
+	var removed []string
+	for _, site := range stateSites {
+		if !slices.Contains(planSites, site) {
+			removed = append(removed, site)
+		}
+	}
+
+	if err := deleteProxyEndpointSites(ctx, r.client.Proxy(), domain, hostname, normalizedAddress, removed); err != nil {
 		resp.Diagnostics.AddError(
 			"Error updating Proxy Endpoint",
-			fmt.Sprintf("Expected 1 endpoint, got %d", len(endpoints)),
+			"Could not remove Proxy Endpoint from a site, unexpected error: "+err.Error(),
 		)
 		return
 	}
 
-	endpoint := endpoints[0]
-	state = ProxyEndpointResourceModel{
-		ID:            types.StringValue(fmt.Sprintf("%s/%s/%s/%s", endpoint.Domain, endpoint.Hostname, endpoint.Address.String(), endpoint.Site)),
-		Domain:        types.StringValue(endpoint.Domain),
-		Hostname:      types.StringValue(endpoint.Hostname),
-		Address:       types.StringValue(endpoint.Address.String()),
-		Site:          types.StringValue(endpoint.Site),
-		ProxyProtocol: types.BoolValue(endpoint.ProxyProtocol),
-	}
+	state = proxyEndpointState(endpoints)
 
 	diags = resp.State.Set(ctx, &state)
 	resp.Diagnostics.Append(diags...)
@@ -416,13 +719,19 @@ func (r *ProxyEndpointResource) Delete(ctx context.Context, req resource.DeleteR
 		return
 	}
 
+	sites, diags := state.sites(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	if state.Domain.IsNull() || state.Domain.IsUnknown() ||
 		state.Hostname.IsNull() || state.Hostname.IsUnknown() ||
 		state.Address.IsNull() || state.Address.IsUnknown() ||
-		state.Site.IsNull() || state.Site.IsUnknown() {
+		len(sites) == 0 {
 		resp.Diagnostics.AddError(
 			"Missing proxy endpoint identity",
-			"Domain, hostname, address, and site must be set in state to delete a proxy endpoint.",
+			"Domain, hostname, address, and sites must be set in state to delete a proxy endpoint.",
 		)
 		return
 	}
@@ -436,12 +745,13 @@ func (r *ProxyEndpointResource) Delete(ctx context.Context, req resource.DeleteR
 		return
 	}
 
-	err = r.client.Proxy().DeleteEndpoints(
+	err = deleteProxyEndpointSites(
 		ctx,
+		r.client.Proxy(),
 		state.Domain.ValueString(),
 		state.Hostname.ValueString(),
 		normalizedAddress,
-		state.Site.ValueString(),
+		sites,
 	)
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -457,7 +767,7 @@ func (r *ProxyEndpointResource) ImportState(ctx context.Context, req resource.Im
 	if len(parts) != 4 {
 		resp.Diagnostics.AddError(
 			"Invalid import ID",
-			"Expected import ID in the format domain/hostname/address/site.",
+			"Expected import ID in the format domain/hostname/address/sites, with sites separated by commas.",
 		)
 		return
 	}
@@ -467,7 +777,7 @@ func (r *ProxyEndpointResource) ImportState(ctx context.Context, req resource.Im
 		if parts[i] == "" {
 			resp.Diagnostics.AddError(
 				"Invalid import ID",
-				"Import ID parts must be non-empty in the format domain/hostname/address/site.",
+				"Import ID parts must be non-empty in the format domain/hostname/address/sites.",
 			)
 			return
 		}
@@ -482,9 +792,29 @@ func (r *ProxyEndpointResource) ImportState(ctx context.Context, req resource.Im
 		return
 	}
 
-	resp.State.SetAttribute(ctx, path.Root("id"), types.StringValue(fmt.Sprintf("%s/%s/%s/%s", parts[0], parts[1], normalizedAddress, parts[3])))
-	resp.State.SetAttribute(ctx, path.Root("domain"), types.StringValue(parts[0]))
-	resp.State.SetAttribute(ctx, path.Root("hostname"), types.StringValue(parts[1]))
-	resp.State.SetAttribute(ctx, path.Root("address"), types.StringValue(normalizedAddress))
-	resp.State.SetAttribute(ctx, path.Root("site"), types.StringValue(parts[3]))
+	var sites []string
+	for _, site := range strings.Split(parts[3], ",") {
+		site = strings.TrimSpace(site)
+		if site == "" {
+			resp.Diagnostics.AddError(
+				"Invalid import ID",
+				"Sites must be non-empty and separated by commas.",
+			)
+			return
+		}
+		sites = append(sites, site)
+	}
+	sort.Strings(sites)
+
+	state := ProxyEndpointResourceModel{
+		ID:            types.StringValue(proxyEndpointID(parts[0], parts[1], normalizedAddress, sites)),
+		Domain:        types.StringValue(parts[0]),
+		Hostname:      types.StringValue(parts[1]),
+		Address:       types.StringValue(normalizedAddress),
+		ProxyProtocol: types.BoolNull(),
+	}
+	state.setSites(sites)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, proxyEndpointImportedKey, []byte(`true`))...)
 }
@@ -6,10 +6,14 @@ package provider
 import (
 	"fmt"
 	"os"
+	"strconv"
+	"strings"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-testing/compare"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
 	"github.com/hashicorp/terraform-plugin-testing/statecheck"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
 	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
@@ -77,6 +81,22 @@ func TestAccProxyEndpointResource(t *testing.T) {
 						tfjsonpath.New("proxy_protocol"),
 						knownvalue.Bool(false),
 					),
+					statecheck.ExpectKnownValue(
+						"mythicbeasts_proxy_endpoint.test",
+						tfjsonpath.New("sites"),
+						knownvalue.SetExact([]knownvalue.Check{
+							knownvalue.StringExact(proxyEndpointSite),
+						}),
+					),
+				},
+			},
+			// The same site given as sites is not a change
+			{
+				Config: testAccProxyEndpointResourceSitesConfig(proxyEndpointPiIdentifier, domain, proxyEndpointHostname, []string{proxyEndpointSite}),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
 				},
 			},
 			{
@@ -136,3 +156,153 @@ resource "mythicbeasts_proxy_endpoint" "test" {
 }
 `, identifier, domain, hostname, site, proxyProtocol)
 }
+
+func testAccProxyEndpointResourceSitesConfig(identifier, domain, hostname string, sites []string) string {
+	quoted := make([]string, len(sites))
+	for i, site := range sites {
+		quoted[i] = strconv.Quote(site)
+	}
+
+	return fmt.Sprintf(`
+resource "mythicbeasts_pi" "proxy" {
+  identifier   = %[1]q
+  disk_size    = 10
+  model        = 4
+  os_image     = "rpi-bookworm-arm64"
+  ssh_key      = "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIPfx70ArvHPF+9U3GgKgNEAWkXSyZMun83sn9582Pl4e code@paultibbetts.uk"
+  wait_for_dns = true
+  memory       = 4096
+}
+
+resource "mythicbeasts_proxy_endpoint" "test" {
+  domain         = %[2]q
+  hostname       = %[3]q
+  address        = mythicbeasts_pi.proxy.ip
+  sites          = [%[4]s]
+  proxy_protocol = false
+}
+`, identifier, domain, hostname, strings.Join(quoted, ", "))
+}
+
+func TestAccProxyEndpointResourceSites(t *testing.T) {
+	proxyEndpointPiIdentifier := testAccIdentifier("tfpx", 20)
+	domain := testAccProxyEndpointDomain(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProxyEndpointResourceSitesConfig(proxyEndpointPiIdentifier, domain, proxyEndpointHostname, []string{"hex", "sov"}),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"mythicbeasts_proxy_endpoint.test",
+						tfjsonpath.New("sites"),
+						knownvalue.SetExact([]knownvalue.Check{
+							knownvalue.StringExact("hex"),
+							knownvalue.StringExact("sov"),
+						}),
+					),
+					statecheck.ExpectKnownValue(
+						"mythicbeasts_proxy_endpoint.test",
+						tfjsonpath.New("site"),
+						knownvalue.Null(),
+					),
+				},
+			},
+			// Changing the sites updates the endpoint in place
+			{
+				Config: testAccProxyEndpointResourceSitesConfig(proxyEndpointPiIdentifier, domain, proxyEndpointHostname, []string{"sov"}),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("mythicbeasts_proxy_endpoint.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"mythicbeasts_proxy_endpoint.test",
+						tfjsonpath.New("sites"),
+						knownvalue.SetExact([]knownvalue.Check{
+							knownvalue.StringExact("sov"),
+						}),
+					),
+					statecheck.ExpectKnownValue(
+						"mythicbeasts_proxy_endpoint.test",
+						tfjsonpath.New("site"),
+						knownvalue.StringExact("sov"),
+					),
+				},
+			},
+		},
+	})
+}
+
+func TestAccProxyEndpointResourceAddressChange(t *testing.T) {
+	blueIdentifier := testAccIdentifier("tfpb", 20)
+	greenIdentifier := testAccIdentifier("tfpg", 20)
+	domain := testAccProxyEndpointDomain(t)
+	resourceAddress := "mythicbeasts_proxy_endpoint.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProxyEndpointResourceAddressConfig(blueIdentifier, greenIdentifier, domain, "blue"),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.CompareValuePairs(
+						resourceAddress,
+						tfjsonpath.New("address"),
+						"mythicbeasts_pi.blue",
+						tfjsonpath.New("ip"),
+						compare.ValuesSame(),
+					),
+				},
+			},
+			// Switching the address updates the endpoint in place
+			{
+				Config: testAccProxyEndpointResourceAddressConfig(blueIdentifier, greenIdentifier, domain, "green"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction(resourceAddress, plancheck.ResourceActionUpdate),
+					},
+				},
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.CompareValuePairs(
+						resourceAddress,
+						tfjsonpath.New("address"),
+						"mythicbeasts_pi.green",
+						tfjsonpath.New("ip"),
+						compare.ValuesSame(),
+					),
+				},
+			},
+		},
+	})
+}
+
+func testAccProxyEndpointResourceAddressConfig(blueIdentifier, greenIdentifier, domain, active string) string {
+	return fmt.Sprintf(`
+resource "mythicbeasts_pi" "blue" {
+  identifier = %[1]q
+  model      = 4
+  memory     = 4096
+  os_image   = "rpi-bookworm-arm64"
+  ssh_key    = %[4]q
+}
+
+resource "mythicbeasts_pi" "green" {
+  identifier = %[2]q
+  model      = 4
+  memory     = 4096
+  os_image   = "rpi-bookworm-arm64"
+  ssh_key    = %[4]q
+}
+
+resource "mythicbeasts_proxy_endpoint" "test" {
+  domain   = %[3]q
+  hostname = %[1]q
+  address  = mythicbeasts_pi.%[5]s.ip
+}
+`, blueIdentifier, greenIdentifier, domain, piSSHKey, active)
+}
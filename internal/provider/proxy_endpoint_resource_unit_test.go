@@ -0,0 +1,150 @@
+// Copyright IBM Corp. 2021, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"net/netip"
+	"reflect"
+	"strings"
+	"testing"
+
+	mbProxy "github.com/paultibbetts/mythicbeasts-client-go/proxy"
+)
+
+func TestProxyEndpointState(t *testing.T) {
+	address := netip.MustParseAddr("2a00:1098::1")
+
+	single := proxyEndpointState([]mbProxy.Endpoint{
+		{Domain: "example.com", Hostname: "www", Address: address, Site: "all"},
+	})
+	if got := single.ID.ValueString(); got != "example.com/www/2a00:1098::1/all" {
+		t.Fatalf("unexpected ID %q", got)
+	}
+	if got := single.Site.ValueString(); got != "all" {
+		t.Fatalf("expected site all, got %q", got)
+	}
+
+	multiple := proxyEndpointState([]mbProxy.Endpoint{
+		{Domain: "example.com", Hostname: "www", Address: address, Site: "hex", ProxyProtocol: true},
+		{Domain: "example.com", Hostname: "www", Address: address, Site: "sov", ProxyProtocol: true},
+	})
+	if got := multiple.ID.ValueString(); got != "example.com/www/2a00:1098::1/hex,sov" {
+		t.Fatalf("unexpected ID %q", got)
+	}
+	if !multiple.Site.IsNull() {
+		t.Fatalf("expected no site for several sites, got %q", multiple.Site.ValueString())
+	}
+	if !multiple.ProxyProtocol.ValueBool() {
+		t.Fatal("expected proxy protocol to be enabled")
+	}
+
+	sites, diags := multiple.sites(context.Background())
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if !reflect.DeepEqual(sites, []string{"hex", "sov"}) {
+		t.Fatalf("unexpected sites %v", sites)
+	}
+}
+
+// fakeProxyEndpointAPI keeps endpoints in memory, keyed by
+// address/site, fails creates in sites in failCreate and fails deletes of
+// addresses in failDelete.
+type fakeProxyEndpointAPI struct {
+	endpoints  map[string]mbProxy.Endpoint
+	failCreate map[string]bool
+	failDelete map[string]bool
+}
+
+func newFakeProxyEndpointAPI(endpoints ...mbProxy.Endpoint) *fakeProxyEndpointAPI {
+	api := &fakeProxyEndpointAPI{endpoints: map[string]mbProxy.Endpoint{}, failCreate: map[string]bool{}, failDelete: map[string]bool{}}
+	for _, endpoint := range endpoints {
+		api.endpoints[endpoint.Address.String()+"/"+endpoint.Site] = endpoint
+	}
+
+	return api
+}
+
+func (f *fakeProxyEndpointAPI) CreateOrUpdateEndpoints(_ context.Context, domain, hostname, address, site string, requests []mbProxy.EndpointRequest) ([]mbProxy.Endpoint, error) {
+	if f.failCreate[site] {
+		return nil, errors.New("internal server error")
+	}
+
+	endpoint := mbProxy.Endpoint{
+		Domain:        domain,
+		Hostname:      hostname,
+		Address:       netip.MustParseAddr(address),
+		Site:          site,
+		ProxyProtocol: requests[0].ProxyProtocol,
+	}
+	f.endpoints[address+"/"+site] = endpoint
+
+	return []mbProxy.Endpoint{endpoint}, nil
+}
+
+func (f *fakeProxyEndpointAPI) GetEndpoint(_ context.Context, _, _, address, site string) (mbProxy.Endpoint, bool, error) {
+	endpoint, ok := f.endpoints[address+"/"+site]
+	return endpoint, ok, nil
+}
+
+func (f *fakeProxyEndpointAPI) DeleteEndpoints(_ context.Context, _, _, address, site string) error {
+	if f.failDelete[address] {
+		return errors.New("internal server error")
+	}
+
+	delete(f.endpoints, address+"/"+site)
+
+	return nil
+}
+
+func (f *fakeProxyEndpointAPI) keys() []string {
+	return sortedKeys(f.endpoints)
+}
+
+func TestMoveProxyEndpoints(t *testing.T) {
+	old := mbProxy.Endpoint{Domain: "example.com", Hostname: "www", Address: netip.MustParseAddr("2a00:1098::1"), Site: "all"}
+	from := proxyEndpointTarget{Address: "2a00:1098::1", Sites: []string{"all"}}
+	to := proxyEndpointTarget{Address: "2a00:1098::2", Sites: []string{"all"}, ProxyProtocol: true}
+
+	t.Run("moves", func(t *testing.T) {
+		api := newFakeProxyEndpointAPI(old)
+
+		endpoints, err := moveProxyEndpoints(context.Background(), api, "example.com", "www", from, to)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if len(endpoints) != 1 || endpoints[0].Address.String() != "2a00:1098::2" || !endpoints[0].ProxyProtocol {
+			t.Fatalf("unexpected endpoints %v", endpoints)
+		}
+		if got := api.keys(); !reflect.DeepEqual(got, []string{"2a00:1098::2/all"}) {
+			t.Fatalf("expected only the new endpoint, got %v", got)
+		}
+	})
+
+	t.Run("rolls back when the delete fails", func(t *testing.T) {
+		api := newFakeProxyEndpointAPI(old)
+		api.failDelete["2a00:1098::1"] = true
+
+		_, err := moveProxyEndpoints(context.Background(), api, "example.com", "www", from, to)
+		if err == nil || !strings.Contains(err.Error(), "deleting the endpoints for 2a00:1098::1") {
+			t.Fatalf("expected a delete error, got %v", err)
+		}
+		if got := api.keys(); !reflect.DeepEqual(got, []string{"2a00:1098::1/all"}) {
+			t.Fatalf("expected only the old endpoint after rolling back, got %v", got)
+		}
+	})
+
+	t.Run("reports a failed roll back", func(t *testing.T) {
+		api := newFakeProxyEndpointAPI(old)
+		api.failDelete["2a00:1098::1"] = true
+		api.failDelete["2a00:1098::2"] = true
+
+		_, err := moveProxyEndpoints(context.Background(), api, "example.com", "www", from, to)
+		if err == nil || !strings.Contains(err.Error(), "removing the endpoint for 2a00:1098::2 in site all") {
+			t.Fatalf("expected a roll back error, got %v", err)
+		}
+	})
+}
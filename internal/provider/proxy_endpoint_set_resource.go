@@ -0,0 +1,506 @@
+// Copyright IBM Corp. 2021, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/paultibbetts/mythicbeasts-client-go"
+	mbProxy "github.com/paultibbetts/mythicbeasts-client-go/proxy"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &ProxyEndpointSetResource{}
+	_ resource.ResourceWithConfigure   = &ProxyEndpointSetResource{}
+	_ resource.ResourceWithImportState = &ProxyEndpointSetResource{}
+)
+
+// NewProxyEndpointSetResource is a helper function to simplify the provider implementation.
+func NewProxyEndpointSetResource() resource.Resource {
+	return &ProxyEndpointSetResource{}
+}
+
+// ProxyEndpointSetResource is the resource implementation.
+type ProxyEndpointSetResource struct {
+	client *mythicbeasts.Client
+}
+
+// ProxyEndpointSetResourceModel maps the resource schema data.
+type ProxyEndpointSetResourceModel struct {
+	ID        types.String `tfsdk:"id"`
+	Domain    types.String `tfsdk:"domain"`
+	Hostname  types.String `tfsdk:"hostname"`
+	Endpoints types.Set    `tfsdk:"endpoints"`
+}
+
+// ProxyEndpointSetEntryModel maps an entry of the endpoints attribute of the proxy endpoint set resource.
+type ProxyEndpointSetEntryModel struct {
+	Address       types.String `tfsdk:"address"`
+	Site          types.String `tfsdk:"site"`
+	ProxyProtocol types.Bool   `tfsdk:"proxy_protocol"`
+}
+
+var proxyEndpointSetEntryAttrTypes = map[string]attr.Type{
+	"address":        types.StringType,
+	"site":           types.StringType,
+	"proxy_protocol": types.BoolType,
+}
+
+var proxyEndpointSetEntryType = types.ObjectType{AttrTypes: proxyEndpointSetEntryAttrTypes}
+
+// proxyEndpointSetEntry is an endpoint of a hostname, with its address
+// normalized.
+type proxyEndpointSetEntry struct {
+	Address       string
+	Site          string
+	ProxyProtocol bool
+}
+
+// key identifies the endpoint within its hostname.
+func (e proxyEndpointSetEntry) key() string {
+	return e.Address + "/" + e.Site
+}
+
+// Metadata returns the resource type name.
+func (r *ProxyEndpointSetResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_proxy_endpoint_set"
+}
+
+// Schema defines the schema for the resource.
+func (r *ProxyEndpointSetResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages every endpoint for a hostname on the IPv4 to IPv6 proxy.\n\n" +
+			"This resource is authoritative: endpoints for the hostname that are not declared in `endpoints`, including ones added outside of Terraform, are removed. " +
+			"Don't use it together with [`mythicbeasts_proxy_endpoint` resources](../resources/proxy_endpoint) or a Pi's `proxy_endpoints` for the same hostname.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Composite identifier for the hostname (domain/hostname).",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"domain": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Domain part of the hostname to be proxied (e.g. \"example.com\").",
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(
+						regexp.MustCompile(`^\S+$`),
+						"must not be empty or contain whitespace",
+					),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"hostname": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Host part of the hostname to be proxied (e.g. \"www\" or \"@\").",
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(
+						regexp.MustCompile(`^\S+$`),
+						"must not be empty or contain whitespace",
+					),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"endpoints": schema.SetNestedAttribute{
+				Required:            true,
+				MarkdownDescription: "Every endpoint for the hostname. Each address and site pair may only appear once.",
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+				},
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"address": schema.StringAttribute{
+							Required:            true,
+							MarkdownDescription: "IPv6 address of the server to which requests are proxied.",
+							Validators: []validator.String{
+								IPv6Address(),
+							},
+						},
+						"site": schema.StringAttribute{
+							Optional:            true,
+							Computed:            true,
+							Default:             stringdefault.StaticString("all"),
+							MarkdownDescription: "Site in which the proxy server is located, or `all` for all sites.\nDefault: `all`",
+							Validators: []validator.String{
+								stringvalidator.RegexMatches(
+									regexp.MustCompile(`^\S+$`),
+									"must not be empty or contain whitespace",
+								),
+							},
+						},
+						"proxy_protocol": schema.BoolAttribute{
+							Optional:            true,
+							Computed:            true,
+							Default:             booldefault.StaticBool(false),
+							MarkdownDescription: "Whether PROXY protocol is enabled for this endpoint.\nDefault: `false`",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *ProxyEndpointSetResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*mythicbeasts.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *mythicbeasts.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+// proxyEndpointSetEntriesFromValue returns the endpoints of the set, with
+// their addresses normalized.
+func proxyEndpointSetEntriesFromValue(ctx context.Context, value types.Set) ([]proxyEndpointSetEntry, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	if value.IsNull() || value.IsUnknown() {
+		return nil, diags
+	}
+
+	var models []ProxyEndpointSetEntryModel
+	diags.Append(value.ElementsAs(ctx, &models, false)...)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	entries := make([]proxyEndpointSetEntry, 0, len(models))
+	seen := make(map[string]bool, len(models))
+	for _, model := range models {
+		address, err := normalizeIPv6(model.Address.ValueString())
+		if err != nil {
+			diags.AddAttributeError(path.Root("endpoints"), "Invalid IPv6 address", err.Error())
+			return nil, diags
+		}
+
+		entry := proxyEndpointSetEntry{
+			Address:       address,
+			Site:          model.Site.ValueString(),
+			ProxyProtocol: model.ProxyProtocol.ValueBool(),
+		}
+		if seen[entry.key()] {
+			diags.AddAttributeError(
+				path.Root("endpoints"),
+				"Duplicate proxy endpoint",
+				fmt.Sprintf("The endpoint for address %s in site %s is declared more than once.", entry.Address, entry.Site),
+			)
+			return nil, diags
+		}
+		seen[entry.key()] = true
+
+		entries = append(entries, entry)
+	}
+	sortProxyEndpointSetEntries(entries)
+
+	return entries, diags
+}
+
+// proxyEndpointSetValue returns the endpoints as a set. Addresses keep the
+// spelling they had in prior, when an entry there has the same address.
+func proxyEndpointSetValue(ctx context.Context, entries []proxyEndpointSetEntry, prior types.Set) (types.Set, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	spellings := map[string]string{}
+	if !prior.IsNull() && !prior.IsUnknown() {
+		var models []ProxyEndpointSetEntryModel
+		diags.Append(prior.ElementsAs(ctx, &models, false)...)
+		if diags.HasError() {
+			return types.SetNull(proxyEndpointSetEntryType), diags
+		}
+
+		for _, model := range models {
+			if address, err := normalizeIPv6(model.Address.ValueString()); err == nil {
+				spellings[address] = model.Address.ValueString()
+			}
+		}
+	}
+
+	models := make([]ProxyEndpointSetEntryModel, 0, len(entries))
+	for _, entry := range entries {
+		address := entry.Address
+		if spelling, ok := spellings[address]; ok {
+			address = spelling
+		}
+
+		models = append(models, ProxyEndpointSetEntryModel{
+			Address:       types.StringValue(address),
+			Site:          types.StringValue(entry.Site),
+			ProxyProtocol: types.BoolValue(entry.ProxyProtocol),
+		})
+	}
+
+	value, d := types.SetValueFrom(ctx, proxyEndpointSetEntryType, models)
+	diags.Append(d...)
+
+	return value, diags
+}
+
+func sortProxyEndpointSetEntries(entries []proxyEndpointSetEntry) {
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].key() < entries[j].key()
+	})
+}
+
+// proxyEndpointSetEntries converts endpoints returned by the API.
+func proxyEndpointSetEntries(endpoints []mbProxy.Endpoint) []proxyEndpointSetEntry {
+	entries := make([]proxyEndpointSetEntry, 0, len(endpoints))
+	for _, endpoint := range endpoints {
+		entries = append(entries, proxyEndpointSetEntry{
+			Address:       endpoint.Address.String(),
+			Site:          endpoint.Site,
+			ProxyProtocol: endpoint.ProxyProtocol,
+		})
+	}
+	sortProxyEndpointSetEntries(entries)
+
+	return entries
+}
+
+// diffProxyEndpointSet returns the endpoints to remove because they are
+// not desired, and the desired endpoints to create or update because they
+// are missing or differ.
+func diffProxyEndpointSet(current, desired []proxyEndpointSetEntry) (remove, apply []proxyEndpointSetEntry) {
+	existing := make(map[string]proxyEndpointSetEntry, len(current))
+	for _, entry := range current {
+		existing[entry.key()] = entry
+	}
+
+	wanted := make(map[string]bool, len(desired))
+	for _, entry := range desired {
+		wanted[entry.key()] = true
+
+		if found, ok := existing[entry.key()]; !ok || found != entry {
+			apply = append(apply, entry)
+		}
+	}
+
+	for _, entry := range current {
+		if !wanted[entry.key()] {
+			remove = append(remove, entry)
+		}
+	}
+
+	return remove, apply
+}
+
+// reconcile makes the endpoints for the hostname match desired, adding
+// and updating endpoints before removing the ones that are not desired.
+func (r *ProxyEndpointSetResource) reconcile(ctx context.Context, domain, hostname string, desired []proxyEndpointSetEntry) error {
+	endpoints, err := r.client.Proxy().ListHostnameEndpoints(ctx, domain, hostname)
+	if err != nil {
+		return fmt.Errorf("listing proxy endpoints: %w", err)
+	}
+
+	remove, apply := diffProxyEndpointSet(proxyEndpointSetEntries(endpoints), desired)
+
+	for _, entry := range apply {
+		tflog.Info(ctx, fmt.Sprintf("pointing proxy endpoint %s.%s at %s in %s", hostname, domain, entry.Address, entry.Site))
+
+		_, err := r.client.Proxy().CreateOrUpdateEndpoints(
+			ctx,
+			domain,
+			hostname,
+			entry.Address,
+			entry.Site,
+			[]mbProxy.EndpointRequest{{Site: entry.Site, ProxyProtocol: entry.ProxyProtocol}},
+		)
+		if err != nil {
+			return fmt.Errorf("creating proxy endpoint %s: %w", entry.key(), err)
+		}
+	}
+
+	for _, entry := range remove {
+		tflog.Info(ctx, fmt.Sprintf("removing proxy endpoint %s.%s from %s in %s", hostname, domain, entry.Address, entry.Site))
+
+		if err := r.client.Proxy().DeleteEndpoints(ctx, domain, hostname, entry.Address, entry.Site); err != nil {
+			return fmt.Errorf("deleting proxy endpoint %s: %w", entry.key(), err)
+		}
+	}
+
+	return nil
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *ProxyEndpointSetResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan ProxyEndpointSetResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	desired, diags := proxyEndpointSetEntriesFromValue(ctx, plan.Endpoints)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	domain := plan.Domain.ValueString()
+	hostname := plan.Hostname.ValueString()
+
+	if err := r.reconcile(ctx, domain, hostname, desired); err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating Proxy Endpoint Set",
+			fmt.Sprintf("Could not set the proxy endpoints for %s/%s: %s", domain, hostname, err.Error()),
+		)
+		return
+	}
+
+	plan.ID = types.StringValue(domain + "/" + hostname)
+
+	diags = resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *ProxyEndpointSetResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state ProxyEndpointSetResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	domain := state.Domain.ValueString()
+	hostname := state.Hostname.ValueString()
+
+	endpoints, err := r.client.Proxy().ListHostnameEndpoints(ctx, domain, hostname)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading Proxy Endpoint Set",
+			fmt.Sprintf("Could not list the proxy endpoints for %s/%s: %s", domain, hostname, err.Error()),
+		)
+		return
+	}
+	if len(endpoints) == 0 {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	state.ID = types.StringValue(domain + "/" + hostname)
+	state.Endpoints, diags = proxyEndpointSetValue(ctx, proxyEndpointSetEntries(endpoints), state.Endpoints)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *ProxyEndpointSetResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan ProxyEndpointSetResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	desired, diags := proxyEndpointSetEntriesFromValue(ctx, plan.Endpoints)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	domain := plan.Domain.ValueString()
+	hostname := plan.Hostname.ValueString()
+
+	if err := r.reconcile(ctx, domain, hostname, desired); err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating Proxy Endpoint Set",
+			fmt.Sprintf("Could not set the proxy endpoints for %s/%s: %s", domain, hostname, err.Error()),
+		)
+		return
+	}
+
+	plan.ID = types.StringValue(domain + "/" + hostname)
+
+	diags = resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *ProxyEndpointSetResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state ProxyEndpointSetResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	domain := state.Domain.ValueString()
+	hostname := state.Hostname.ValueString()
+
+	if err := r.reconcile(ctx, domain, hostname, nil); err != nil {
+		resp.Diagnostics.AddError(
+			"Error deleting Proxy Endpoint Set",
+			fmt.Sprintf("Could not remove the proxy endpoints for %s/%s: %s", domain, hostname, err.Error()),
+		)
+		return
+	}
+}
+
+func (r *ProxyEndpointSetResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	domain, hostname, ok := strings.Cut(req.ID, "/")
+	domain = strings.TrimSpace(domain)
+	hostname = strings.TrimSpace(hostname)
+	if !ok || domain == "" || hostname == "" || strings.Contains(hostname, "/") {
+		resp.Diagnostics.AddError(
+			"Invalid import ID",
+			"Expected import ID in the format domain/hostname.",
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), types.StringValue(domain+"/"+hostname))...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("domain"), types.StringValue(domain))...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("hostname"), types.StringValue(hostname))...)
+}
@@ -0,0 +1,105 @@
+// Copyright IBM Corp. 2021, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+func TestAccProxyEndpointSetResource(t *testing.T) {
+	piIdentifier := testAccIdentifier("tfps", 20)
+	domain := testAccProxyEndpointDomain(t)
+	resourceAddress := "mythicbeasts_proxy_endpoint_set.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccProxyEndpointSetResourceConfig(piIdentifier, domain, false),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						resourceAddress,
+						tfjsonpath.New("id"),
+						knownvalue.StringExact(domain+"/"+piIdentifier),
+					),
+					statecheck.ExpectKnownValue(
+						resourceAddress,
+						tfjsonpath.New("endpoints"),
+						knownvalue.SetExact([]knownvalue.Check{
+							knownvalue.ObjectExact(map[string]knownvalue.Check{
+								"address":        knownvalue.NotNull(),
+								"site":           knownvalue.StringExact("all"),
+								"proxy_protocol": knownvalue.Bool(false),
+							}),
+						}),
+					),
+				},
+			},
+			// ImportState testing
+			{
+				ResourceName:                         resourceAddress,
+				ImportState:                          true,
+				ImportStateVerify:                    true,
+				ImportStateId:                        domain + "/" + piIdentifier,
+				ImportStateVerifyIdentifierAttribute: "id",
+			},
+			// Update the endpoint in place
+			{
+				Config: testAccProxyEndpointSetResourceConfig(piIdentifier, domain, true),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction(resourceAddress, plancheck.ResourceActionUpdate),
+					},
+				},
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						resourceAddress,
+						tfjsonpath.New("endpoints"),
+						knownvalue.SetExact([]knownvalue.Check{
+							knownvalue.ObjectPartial(map[string]knownvalue.Check{
+								"proxy_protocol": knownvalue.Bool(true),
+							}),
+						}),
+					),
+				},
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccProxyEndpointSetResourceConfig(identifier, domain string, proxyProtocol bool) string {
+	return fmt.Sprintf(`
+resource "mythicbeasts_pi" "proxy" {
+  identifier   = %[1]q
+  disk_size    = 10
+  model        = 4
+  os_image     = "rpi-bookworm-arm64"
+  ssh_key      = %[3]q
+  wait_for_dns = true
+  memory       = 4096
+}
+
+resource "mythicbeasts_proxy_endpoint_set" "test" {
+  domain   = %[2]q
+  hostname = %[1]q
+
+  endpoints = [
+    {
+      address        = mythicbeasts_pi.proxy.ip
+      proxy_protocol = %[4]t
+    },
+  ]
+}
+`, identifier, domain, piSSHKey, proxyProtocol)
+}
@@ -0,0 +1,65 @@
+// Copyright IBM Corp. 2021, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDiffProxyEndpointSet(t *testing.T) {
+	blue := proxyEndpointSetEntry{Address: "2a00:1098::1", Site: "all"}
+	green := proxyEndpointSetEntry{Address: "2a00:1098::2", Site: "all"}
+	greenProxied := proxyEndpointSetEntry{Address: "2a00:1098::2", Site: "all", ProxyProtocol: true}
+	blueSov := proxyEndpointSetEntry{Address: "2a00:1098::1", Site: "sov"}
+
+	tests := []struct {
+		name       string
+		current    []proxyEndpointSetEntry
+		desired    []proxyEndpointSetEntry
+		wantRemove []proxyEndpointSetEntry
+		wantApply  []proxyEndpointSetEntry
+	}{
+		{
+			name:    "unchanged",
+			current: []proxyEndpointSetEntry{blue, green},
+			desired: []proxyEndpointSetEntry{blue, green},
+		},
+		{
+			name:      "create",
+			current:   []proxyEndpointSetEntry{blue},
+			desired:   []proxyEndpointSetEntry{blue, green},
+			wantApply: []proxyEndpointSetEntry{green},
+		},
+		{
+			name:      "update",
+			current:   []proxyEndpointSetEntry{blue, green},
+			desired:   []proxyEndpointSetEntry{blue, greenProxied},
+			wantApply: []proxyEndpointSetEntry{greenProxied},
+		},
+		{
+			name:       "remove undeclared",
+			current:    []proxyEndpointSetEntry{blue, blueSov, green},
+			desired:    []proxyEndpointSetEntry{green},
+			wantRemove: []proxyEndpointSetEntry{blue, blueSov},
+		},
+		{
+			name:       "remove all",
+			current:    []proxyEndpointSetEntry{blue, green},
+			wantRemove: []proxyEndpointSetEntry{blue, green},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			remove, apply := diffProxyEndpointSet(tt.current, tt.desired)
+			if !reflect.DeepEqual(remove, tt.wantRemove) {
+				t.Fatalf("remove = %v, want %v", remove, tt.wantRemove)
+			}
+			if !reflect.DeepEqual(apply, tt.wantApply) {
+				t.Fatalf("apply = %v, want %v", apply, tt.wantApply)
+			}
+		})
+	}
+}
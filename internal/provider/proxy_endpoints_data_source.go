@@ -0,0 +1,245 @@
+// Copyright IBM Corp. 2021, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/paultibbetts/mythicbeasts-client-go"
+	mbProxy "github.com/paultibbetts/mythicbeasts-client-go/proxy"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &ProxyEndpointsDataSource{}
+	_ datasource.DataSourceWithConfigure = &ProxyEndpointsDataSource{}
+)
+
+// NewProxyEndpointsDataSource is a helper function to simplify the provider implementation.
+func NewProxyEndpointsDataSource() datasource.DataSource {
+	return &ProxyEndpointsDataSource{}
+}
+
+// ProxyEndpointsDataSource is the data source implementation.
+type ProxyEndpointsDataSource struct {
+	client *mythicbeasts.Client
+}
+
+type ProxyEndpointsDataSourceModel struct {
+	Domain    types.String         `tfsdk:"domain"`
+	Hostname  types.String         `tfsdk:"hostname"`
+	Address   types.String         `tfsdk:"address"`
+	Site      types.String         `tfsdk:"site"`
+	Endpoints []ProxyEndpointModel `tfsdk:"endpoints"`
+}
+
+type ProxyEndpointModel struct {
+	Domain        types.String `tfsdk:"domain"`
+	Hostname      types.String `tfsdk:"hostname"`
+	Address       types.String `tfsdk:"address"`
+	Site          types.String `tfsdk:"site"`
+	ProxyProtocol types.Bool   `tfsdk:"proxy_protocol"`
+}
+
+// Metadata returns the data source type name.
+func (d *ProxyEndpointsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_proxy_endpoints"
+}
+
+// Schema defines the schema for the data source.
+func (d *ProxyEndpointsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Returns the endpoints configured on the IPv4 to IPv6 proxy, optionally filtered. See the [`mythicbeasts_proxy_endpoint` resource](../resources/proxy_endpoint) to manage them.",
+		Attributes: map[string]schema.Attribute{
+			"domain": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only return endpoints for this domain (e.g. \"example.com\")",
+			},
+			"hostname": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only return endpoints for this host part of the hostname (e.g. \"www\" or \"@\")",
+			},
+			"address": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only return endpoints proxying to this IPv6 address",
+				Validators: []validator.String{
+					IPv6Address(),
+				},
+			},
+			"site": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only return endpoints in this site. Endpoints in `all` sites are only returned when filtering for `all`.",
+			},
+			"endpoints": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "Matching endpoints, ordered by domain, hostname, address and site",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"domain": schema.StringAttribute{
+							Computed: true,
+						},
+						"hostname": schema.StringAttribute{
+							Computed: true,
+						},
+						"address": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "IPv6 address requests are proxied to, in canonical form",
+						},
+						"site": schema.StringAttribute{
+							Computed: true,
+						},
+						"proxy_protocol": schema.BoolAttribute{
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *ProxyEndpointsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config ProxyEndpointsDataSourceModel // for input
+	configDiags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(configDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	filter := proxyEndpointFilter{
+		Domain:   config.Domain.ValueString(),
+		Hostname: config.Hostname.ValueString(),
+		Site:     config.Site.ValueString(),
+	}
+	if !config.Address.IsNull() {
+		address, err := normalizeIPv6(config.Address.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("address"), "Invalid IPv6 address", err.Error())
+			return
+		}
+		filter.Address = address
+	}
+
+	// The API can list a single hostname; anything broader is filtered
+	// from the full list.
+	var endpoints []mbProxy.Endpoint
+	var err error
+	if filter.Domain != "" && filter.Hostname != "" {
+		endpoints, err = d.client.Proxy().ListHostnameEndpoints(ctx, filter.Domain, filter.Hostname)
+	} else {
+		endpoints, err = d.client.Proxy().ListEndpoints(ctx)
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to read Mythic Beasts proxy endpoints",
+			err.Error(),
+		)
+		return
+	}
+
+	state := ProxyEndpointsDataSourceModel{
+		Domain:    config.Domain,
+		Hostname:  config.Hostname,
+		Address:   config.Address,
+		Site:      config.Site,
+		Endpoints: []ProxyEndpointModel{},
+	}
+
+	// Map response body to model
+	for _, endpoint := range filterProxyEndpoints(endpoints, filter) {
+		state.Endpoints = append(state.Endpoints, ProxyEndpointModel{
+			Domain:        types.StringValue(endpoint.Domain),
+			Hostname:      types.StringValue(endpoint.Hostname),
+			Address:       types.StringValue(endpoint.Address.String()),
+			Site:          types.StringValue(endpoint.Site),
+			ProxyProtocol: types.BoolValue(endpoint.ProxyProtocol),
+		})
+	}
+
+	// Set state
+	diags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// proxyEndpointFilter selects proxy endpoints; empty fields match every
+// endpoint.
+type proxyEndpointFilter struct {
+	Domain   string
+	Hostname string
+	Address  string
+	Site     string
+}
+
+// filterProxyEndpoints returns the endpoints matching the filter, in order.
+// The address filter must already be normalized.
+func filterProxyEndpoints(endpoints []mbProxy.Endpoint, filter proxyEndpointFilter) []mbProxy.Endpoint {
+	filtered := []mbProxy.Endpoint{}
+	for _, endpoint := range endpoints {
+		if filter.Domain != "" && endpoint.Domain != filter.Domain {
+			continue
+		}
+
+		if filter.Hostname != "" && endpoint.Hostname != filter.Hostname {
+			continue
+		}
+
+		if filter.Address != "" && endpoint.Address.String() != filter.Address {
+			continue
+		}
+
+		if filter.Site != "" && endpoint.Site != filter.Site {
+			continue
+		}
+
+		filtered = append(filtered, endpoint)
+	}
+
+	sort.Slice(filtered, func(i, j int) bool {
+		a, b := filtered[i], filtered[j]
+		if a.Domain != b.Domain {
+			return a.Domain < b.Domain
+		}
+		if a.Hostname != b.Hostname {
+			return a.Hostname < b.Hostname
+		}
+		if a.Address != b.Address {
+			return a.Address.Less(b.Address)
+		}
+		return a.Site < b.Site
+	})
+
+	return filtered
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *ProxyEndpointsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*mythicbeasts.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *mythicbeasts.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
@@ -0,0 +1,79 @@
+// Copyright IBM Corp. 2021, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/compare"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+func TestAccProxyEndpointsDataSource(t *testing.T) {
+	piIdentifier := testAccIdentifier("tfpd", 20)
+	domain := testAccProxyEndpointDomain(t)
+	dataSourceAddress := "data.mythicbeasts_proxy_endpoints.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: testAccProxyEndpointsDataSourceConfig(piIdentifier, domain),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						dataSourceAddress,
+						tfjsonpath.New("endpoints"),
+						knownvalue.ListExact([]knownvalue.Check{
+							knownvalue.ObjectExact(map[string]knownvalue.Check{
+								"domain":         knownvalue.StringExact(domain),
+								"hostname":       knownvalue.StringExact(piIdentifier),
+								"address":        knownvalue.NotNull(),
+								"site":           knownvalue.StringExact("all"),
+								"proxy_protocol": knownvalue.Bool(false),
+							}),
+						}),
+					),
+					statecheck.CompareValuePairs(
+						dataSourceAddress,
+						tfjsonpath.New("endpoints").AtSliceIndex(0).AtMapKey("address"),
+						"mythicbeasts_pi.proxy",
+						tfjsonpath.New("ip"),
+						compare.ValuesSame(),
+					),
+				},
+			},
+		},
+	})
+}
+
+func testAccProxyEndpointsDataSourceConfig(identifier, domain string) string {
+	return fmt.Sprintf(`
+resource "mythicbeasts_pi" "proxy" {
+  identifier   = %[1]q
+  disk_size    = 10
+  model        = 4
+  os_image     = "rpi-bookworm-arm64"
+  ssh_key      = %[3]q
+  wait_for_dns = true
+  memory       = 4096
+}
+
+resource "mythicbeasts_proxy_endpoint" "test" {
+  domain   = %[2]q
+  hostname = %[1]q
+  address  = mythicbeasts_pi.proxy.ip
+}
+
+data "mythicbeasts_proxy_endpoints" "test" {
+  domain   = mythicbeasts_proxy_endpoint.test.domain
+  hostname = mythicbeasts_proxy_endpoint.test.hostname
+}
+`, identifier, domain, piSSHKey)
+}
@@ -0,0 +1,42 @@
+// Copyright IBM Corp. 2021, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"net/netip"
+	"testing"
+
+	mbProxy "github.com/paultibbetts/mythicbeasts-client-go/proxy"
+)
+
+func TestFilterProxyEndpoints(t *testing.T) {
+	endpoints := []mbProxy.Endpoint{
+		{Domain: "example.org", Hostname: "www", Address: netip.MustParseAddr("2a00:1098::2"), Site: "all"},
+		{Domain: "example.com", Hostname: "www", Address: netip.MustParseAddr("2a00:1098::2"), Site: "all"},
+		{Domain: "example.com", Hostname: "www", Address: netip.MustParseAddr("2a00:1098::1"), Site: "sov"},
+		{Domain: "example.com", Hostname: "api", Address: netip.MustParseAddr("2a00:1098::1"), Site: "all"},
+	}
+
+	got := filterProxyEndpoints(endpoints, proxyEndpointFilter{})
+	if len(got) != len(endpoints) {
+		t.Fatalf("expected every endpoint without filters, got %v", got)
+	}
+	if got[0].Hostname != "api" || got[1].Address.String() != "2a00:1098::1" || got[3].Domain != "example.org" {
+		t.Fatalf("expected endpoints in order, got %v", got)
+	}
+
+	got = filterProxyEndpoints(endpoints, proxyEndpointFilter{Domain: "example.com", Hostname: "www"})
+	if len(got) != 2 {
+		t.Fatalf("expected both www.example.com endpoints, got %v", got)
+	}
+
+	got = filterProxyEndpoints(endpoints, proxyEndpointFilter{Address: "2a00:1098::1", Site: "all"})
+	if len(got) != 1 || got[0].Hostname != "api" {
+		t.Fatalf("expected only api.example.com, got %v", got)
+	}
+
+	if got := filterProxyEndpoints(endpoints, proxyEndpointFilter{Domain: "example.net"}); len(got) != 0 {
+		t.Fatalf("expected no endpoints, got %v", got)
+	}
+}
@@ -6,6 +6,7 @@ package provider
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 )
@@ -69,3 +70,98 @@ func (v multipleOfValidator) ValidateInt64(ctx context.Context, req validator.In
 func MultipleOf(divisor int64) validator.Int64 {
 	return multipleOfValidator{divisor: divisor}
 }
+
+type durationValidator struct{}
+
+func (v durationValidator) Description(ctx context.Context) string {
+	return "Value must be a positive duration such as 30s, 5m or 1h"
+}
+
+func (v durationValidator) MarkdownDescription(ctx context.Context) string {
+	return "Value must be a **positive duration** such as `30s`, `5m` or `1h`"
+}
+
+func (v durationValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsUnknown() || req.ConfigValue.IsNull() {
+		return
+	}
+
+	val := req.ConfigValue.ValueString()
+	d, err := time.ParseDuration(val)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Value",
+			fmt.Sprintf("Value %q is not a valid duration: %s", val, err.Error()),
+		)
+		return
+	}
+
+	if d <= 0 {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Value",
+			fmt.Sprintf("Value %q must be a positive duration", val),
+		)
+	}
+}
+
+func Duration() validator.String {
+	return durationValidator{}
+}
+
+type sshPublicKeyValidator struct{}
+
+func (v sshPublicKeyValidator) Description(ctx context.Context) string {
+	return "Value must be a public SSH key in authorized_keys format"
+}
+
+func (v sshPublicKeyValidator) MarkdownDescription(ctx context.Context) string {
+	return "Value must be a **public SSH key** in `authorized_keys` format"
+}
+
+func (v sshPublicKeyValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsUnknown() || req.ConfigValue.IsNull() {
+		return
+	}
+
+	if _, err := parseSSHPublicKey(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Value",
+			fmt.Sprintf("Value is not a valid public SSH key: %s", err.Error()),
+		)
+	}
+}
+
+func SSHPublicKey() validator.String {
+	return sshPublicKeyValidator{}
+}
+
+type ipv6AddressValidator struct{}
+
+func (v ipv6AddressValidator) Description(ctx context.Context) string {
+	return "Value must be an IPv6 address"
+}
+
+func (v ipv6AddressValidator) MarkdownDescription(ctx context.Context) string {
+	return "Value must be an **IPv6 address**"
+}
+
+func (v ipv6AddressValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsUnknown() || req.ConfigValue.IsNull() {
+		return
+	}
+
+	if _, err := normalizeIPv6(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Value",
+			fmt.Sprintf("Value is not a valid IPv6 address: %s", err.Error()),
+		)
+	}
+}
+
+func IPv6Address() validator.String {
+	return ipv6AddressValidator{}
+}
@@ -0,0 +1,131 @@
+// Copyright IBM Corp. 2021, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/paultibbetts/mythicbeasts-client-go"
+	mbVPS "github.com/paultibbetts/mythicbeasts-client-go/vps"
+)
+
+const defaultVPSDiskType = "ssd"
+
+// vpsHostDemand is the RAM and disk, in MB, that a VPS needs from its
+// private cloud host on top of what it already uses.
+type vpsHostDemand struct {
+	RAM int64
+	SSD int64
+	HDD int64
+}
+
+func newVPSHostDemand(ram int64, disk int64, diskType string) vpsHostDemand {
+	demand := vpsHostDemand{RAM: max(ram, 0)}
+
+	if strings.EqualFold(diskType, "hdd") {
+		demand.HDD = max(disk, 0)
+	} else {
+		demand.SSD = max(disk, 0)
+	}
+
+	return demand
+}
+
+// vpsCapacity tracks the demand planned against each private cloud host
+// during a Terraform run, and serialises creates and spec updates on a
+// host so that they cannot overcommit it.
+type vpsCapacity struct {
+	mu      sync.Mutex
+	planned map[string]map[string]vpsHostDemand
+	locks   map[string]*sync.Mutex
+}
+
+// vpsCapacities holds one vpsCapacity per configured client, as every
+// resource configured by the same provider instance shares its client.
+var vpsCapacities sync.Map
+
+func vpsCapacityFor(client *mythicbeasts.Client) *vpsCapacity {
+	capacity, _ := vpsCapacities.LoadOrStore(client, &vpsCapacity{
+		planned: map[string]map[string]vpsHostDemand{},
+		locks:   map[string]*sync.Mutex{},
+	})
+
+	return capacity.(*vpsCapacity)
+}
+
+// plan records the demand of a server on a host, replacing any earlier
+// demand for the same server, and returns the total planned for the host.
+func (c *vpsCapacity) plan(host string, identifier string, demand vpsHostDemand) vpsHostDemand {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.planned[host] == nil {
+		c.planned[host] = map[string]vpsHostDemand{}
+	}
+	c.planned[host][identifier] = demand
+
+	var total vpsHostDemand
+	for _, d := range c.planned[host] {
+		total.RAM += d.RAM
+		total.SSD += d.SSD
+		total.HDD += d.HDD
+	}
+
+	return total
+}
+
+// release forgets the demand of a server once it has been applied, as it
+// is then included in the free capacity reported by the API.
+func (c *vpsCapacity) release(host string, identifier string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.planned[host], identifier)
+}
+
+// lock holds the per-host lock until the returned function is called.
+func (c *vpsCapacity) lock(host string) func() {
+	c.mu.Lock()
+	hostLock, ok := c.locks[host]
+	if !ok {
+		hostLock = &sync.Mutex{}
+		c.locks[host] = hostLock
+	}
+	c.mu.Unlock()
+
+	hostLock.Lock()
+
+	return hostLock.Unlock
+}
+
+// checkHostCapacity returns an error describing every resource on the host
+// that the total demand would exceed. A single server is also checked
+// against the host's total cores, as free cores are not reported.
+func checkHostCapacity(host mbVPS.Host, total vpsHostDemand, cores int64) error {
+	var problems []string
+
+	if total.RAM > host.FreeRAM {
+		problems = append(problems, fmt.Sprintf("%d MB of RAM is planned but %d MB is free", total.RAM, host.FreeRAM))
+	}
+
+	if total.SSD > host.FreeDisk.SSD {
+		problems = append(problems, fmt.Sprintf("%d MB of SSD is planned but %d MB is free", total.SSD, host.FreeDisk.SSD))
+	}
+
+	if total.HDD > host.FreeDisk.HDD {
+		problems = append(problems, fmt.Sprintf("%d MB of HDD is planned but %d MB is free", total.HDD, host.FreeDisk.HDD))
+	}
+
+	if cores > host.Cores {
+		problems = append(problems, fmt.Sprintf("%d cores are planned but the host has %d", cores, host.Cores))
+	}
+
+	if len(problems) == 0 {
+		return nil
+	}
+
+	return fmt.Errorf("host %s does not have enough capacity: %s", host.Name, strings.Join(problems, "; "))
+}
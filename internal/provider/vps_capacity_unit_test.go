@@ -0,0 +1,70 @@
+// Copyright IBM Corp. 2021, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/paultibbetts/mythicbeasts-client-go"
+	mbVPS "github.com/paultibbetts/mythicbeasts-client-go/vps"
+)
+
+func TestVPSCapacityPlanSumsDemandPerHost(t *testing.T) {
+	capacity := vpsCapacityFor(&mythicbeasts.Client{})
+
+	capacity.plan("host1", "a", newVPSHostDemand(1024, 10240, "ssd"))
+	capacity.plan("host2", "b", newVPSHostDemand(4096, 10240, "ssd"))
+	total := capacity.plan("host1", "c", newVPSHostDemand(2048, 20480, "hdd"))
+
+	want := vpsHostDemand{RAM: 3072, SSD: 10240, HDD: 20480}
+	if total != want {
+		t.Fatalf("expected %+v, got %+v", want, total)
+	}
+
+	// Planning the same server again replaces its demand.
+	total = capacity.plan("host1", "c", newVPSHostDemand(1024, 0, "hdd"))
+	want = vpsHostDemand{RAM: 2048, SSD: 10240}
+	if total != want {
+		t.Fatalf("expected %+v, got %+v", want, total)
+	}
+
+	capacity.release("host1", "a")
+	total = capacity.plan("host1", "c", newVPSHostDemand(1024, 0, "hdd"))
+	want = vpsHostDemand{RAM: 1024}
+	if total != want {
+		t.Fatalf("expected %+v after release, got %+v", want, total)
+	}
+}
+
+func TestNewVPSHostDemandIgnoresDecreases(t *testing.T) {
+	got := newVPSHostDemand(-1024, -10240, "ssd")
+	if got != (vpsHostDemand{}) {
+		t.Fatalf("expected no demand, got %+v", got)
+	}
+}
+
+func TestCheckHostCapacity(t *testing.T) {
+	host := mbVPS.Host{
+		Name:     "host1",
+		Cores:    8,
+		FreeRAM:  4096,
+		FreeDisk: mbVPS.HostDisk{SSD: 20480, HDD: 0},
+	}
+
+	if err := checkHostCapacity(host, vpsHostDemand{RAM: 4096, SSD: 20480}, 8); err != nil {
+		t.Fatalf("expected demand to fit, got %s", err)
+	}
+
+	if err := checkHostCapacity(host, vpsHostDemand{RAM: 4097}, 1); err == nil {
+		t.Fatalf("expected an error when RAM is exceeded")
+	}
+
+	if err := checkHostCapacity(host, vpsHostDemand{HDD: 1}, 1); err == nil {
+		t.Fatalf("expected an error when HDD is exceeded")
+	}
+
+	if err := checkHostCapacity(host, vpsHostDemand{}, 9); err == nil {
+		t.Fatalf("expected an error when cores are exceeded")
+	}
+}
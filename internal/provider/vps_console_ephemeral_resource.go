@@ -0,0 +1,146 @@
+// Copyright IBM Corp. 2021, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/paultibbetts/mythicbeasts-client-go"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ ephemeral.EphemeralResource              = &VPSConsoleEphemeralResource{}
+	_ ephemeral.EphemeralResourceWithConfigure = &VPSConsoleEphemeralResource{}
+)
+
+// NewVPSConsoleEphemeralResource is a helper function to simplify the provider implementation.
+func NewVPSConsoleEphemeralResource() ephemeral.EphemeralResource {
+	return &VPSConsoleEphemeralResource{}
+}
+
+// VPSConsoleEphemeralResource is the ephemeral resource implementation.
+type VPSConsoleEphemeralResource struct {
+	client *mythicbeasts.Client
+}
+
+// VPSConsoleEphemeralResourceModel maps the ephemeral resource schema data.
+type VPSConsoleEphemeralResourceModel struct {
+	Identifier types.String `tfsdk:"identifier"`
+	Host       types.String `tfsdk:"host"`
+	IPv4       types.String `tfsdk:"ipv4"`
+	IPv6       types.String `tfsdk:"ipv6"`
+	Port       types.Int64  `tfsdk:"port"`
+	Display    types.Int64  `tfsdk:"display"`
+	Password   types.String `tfsdk:"password"`
+}
+
+// Metadata returns the ephemeral resource type name.
+func (e *VPSConsoleEphemeralResource) Metadata(_ context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_vps_console"
+}
+
+// Schema defines the schema for the ephemeral resource.
+func (e *VPSConsoleEphemeralResource) Schema(_ context.Context, _ ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Returns the VNC console connection details for a [`mythicbeasts_vps` resource](../resources/vps).\n\n" +
+			"The values, including the VNC password, are read from the API while Terraform is running and are never stored in state or plan files.",
+		Attributes: map[string]schema.Attribute{
+			"identifier": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Identifier of the server",
+			},
+			"host": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "VNC host; the IPv4 address when available, otherwise the IPv6 address",
+			},
+			"ipv4": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "VNC IPv4 address",
+			},
+			"ipv6": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "VNC IPv6 address",
+			},
+			"port": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "VNC port number",
+			},
+			"display": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "VNC display number",
+			},
+			"password": schema.StringAttribute{
+				Computed:            true,
+				Sensitive:           true,
+				MarkdownDescription: "VNC password",
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the ephemeral resource.
+func (e *VPSConsoleEphemeralResource) Configure(_ context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*mythicbeasts.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Ephemeral Resource Configure Type",
+			fmt.Sprintf("Expected *mythicbeasts.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	e.client = client
+}
+
+// Open reads the live console details for the server.
+func (e *VPSConsoleEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	var config VPSConsoleEphemeralResourceModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	server, err := e.client.VPS().Get(ctx, config.Identifier.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading Mythic Beasts VPS",
+			"Could not read VPS "+config.Identifier.String()+": "+err.Error(),
+		)
+		return
+	}
+
+	host := server.VNC.IPv4
+	if host == "" {
+		host = server.VNC.IPv6
+	}
+
+	result := VPSConsoleEphemeralResourceModel{
+		Identifier: config.Identifier,
+		Host:       types.StringValue(host),
+		IPv4:       types.StringValue(server.VNC.IPv4),
+		IPv6:       types.StringValue(server.VNC.IPv6),
+		Port:       types.Int64Value(server.VNC.Port),
+		Display:    types.Int64Value(server.VNC.Display),
+		Password:   types.StringValue(server.VNC.Password),
+	}
+
+	diags = resp.Result.Set(ctx, &result)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
@@ -0,0 +1,84 @@
+// Copyright IBM Corp. 2021, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccVPSConsoleEphemeralResource(t *testing.T) {
+	identifier := testAccIdentifier("tfvnc", 20)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactoriesWithEcho,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_10_0),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testAccVPSConsoleEphemeralResourceConfig(identifier),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"echo.test",
+						tfjsonpath.New("data").AtMapKey("identifier"),
+						knownvalue.StringExact(identifier),
+					),
+					statecheck.ExpectKnownValue(
+						"echo.test",
+						tfjsonpath.New("data").AtMapKey("host"),
+						knownvalue.NotNull(),
+					),
+					statecheck.ExpectKnownValue(
+						"echo.test",
+						tfjsonpath.New("data").AtMapKey("port"),
+						knownvalue.NotNull(),
+					),
+					statecheck.ExpectKnownValue(
+						"mythicbeasts_vps.test",
+						tfjsonpath.New("vnc"),
+						knownvalue.ObjectPartial(map[string]knownvalue.Check{
+							"port": knownvalue.NotNull(),
+						}),
+					),
+				},
+			},
+		},
+	})
+}
+
+func testAccVPSConsoleEphemeralResourceConfig(identifier string) string {
+	return fmt.Sprintf(`
+resource "mythicbeasts_vps" "test" {
+  identifier     = %[1]q
+  name           = %[1]q
+  disk_size      = 10240
+  image          = "cloudinit-ubuntu-noble.raw.gz"
+  ipv4_enabled   = false
+  product        = "VPSX4"
+  ssh_keys       = "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIPfx70ArvHPF+9U3GgKgNEAWkXSyZMun83sn9582Pl4e code@paultibbetts.uk"
+  create_in_zone = "uk"
+
+  vnc_password_wo         = "tf-acc-vnc"
+  vnc_password_wo_version = 1
+}
+
+ephemeral "mythicbeasts_vps_console" "test" {
+  identifier = mythicbeasts_vps.test.identifier
+}
+
+provider "echo" {
+  data = ephemeral.mythicbeasts_vps_console.test
+}
+
+resource "echo" "test" {}
+`, identifier)
+}
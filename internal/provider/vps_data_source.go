@@ -0,0 +1,300 @@
+// Copyright IBM Corp. 2021, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/paultibbetts/mythicbeasts-client-go"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &vpsDataSource{}
+	_ datasource.DataSourceWithConfigure = &vpsDataSource{}
+)
+
+// NewVPSDataSource is a helper function to simplify the provider implementation.
+func NewVPSDataSource() datasource.DataSource {
+	return &vpsDataSource{}
+}
+
+// vpsDataSource is the data source implementation.
+type vpsDataSource struct {
+	client *mythicbeasts.Client
+}
+
+type vpsDataSourceModel struct {
+	Identifier types.String  `tfsdk:"identifier"`
+	Name       types.String  `tfsdk:"name"`
+	Product    types.String  `tfsdk:"product"`
+	Family     types.String  `tfsdk:"family"`
+	HostServer types.String  `tfsdk:"host_server"`
+	CPUMode    types.String  `tfsdk:"cpu_mode"`
+	NetDevice  types.String  `tfsdk:"net_device"`
+	DiskBus    types.String  `tfsdk:"disk_bus"`
+	Tablet     types.Bool    `tfsdk:"tablet"`
+	ISOImage   types.String  `tfsdk:"iso_image"`
+	BootDevice types.String  `tfsdk:"boot_device"`
+	Price      types.Float64 `tfsdk:"price"`
+	Period     types.String  `tfsdk:"period"`
+	Dormant    types.Bool    `tfsdk:"dormant"`
+	IPv4       types.Set     `tfsdk:"ipv4"`
+	IPv6       types.Set     `tfsdk:"ipv6"`
+	Macs       types.List    `tfsdk:"macs"`
+	Zone       types.Object  `tfsdk:"zone"`
+	Specs      types.Object  `tfsdk:"specs"`
+	SSHProxy   types.Object  `tfsdk:"ssh_proxy"`
+	VNC        types.Object  `tfsdk:"vnc"`
+}
+
+// Metadata returns the data source type name.
+func (d *vpsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_vps"
+}
+
+// Schema defines the schema for the data source.
+func (d *vpsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Returns an existing VPS by identifier, such as one managed outside this configuration. See the [`mythicbeasts_vps` resource](../resources/vps) to manage a VPS.",
+		Attributes: map[string]schema.Attribute{
+			"identifier": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Identifier of the VPS",
+			},
+			"name": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Name of the server",
+			},
+			"product": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Virtual server product code",
+			},
+			"family": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Product family code",
+			},
+			"host_server": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Name of the private cloud host server, if any",
+			},
+			"cpu_mode": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "CPU mode",
+			},
+			"net_device": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Virtual network device type",
+			},
+			"disk_bus": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Virtual disk bus adapter type",
+			},
+			"tablet": schema.BoolAttribute{
+				Computed:            true,
+				MarkdownDescription: "Tablet mode for VNC mouse pointer",
+			},
+			"iso_image": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "ISO image currently in virtual CD drive",
+			},
+			"boot_device": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Boot device",
+			},
+			"price": schema.Float64Attribute{
+				Computed:            true,
+				MarkdownDescription: "Price of server (pence per billing period)",
+			},
+			"period": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Billing period",
+			},
+			"dormant": schema.BoolAttribute{
+				Computed:            true,
+				MarkdownDescription: "Whether the server is dormant",
+			},
+			"ipv4": schema.SetAttribute{
+				Computed:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "List of IPv4 addresses, if IPv4 is enabled",
+			},
+			"ipv6": schema.SetAttribute{
+				Computed:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "List of IPv6 addresses",
+			},
+			"macs": schema.ListAttribute{
+				Computed:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "List of MAC addresses",
+			},
+			"zone": schema.SingleNestedAttribute{
+				Computed: true,
+				Attributes: map[string]schema.Attribute{
+					"code": schema.StringAttribute{
+						Computed:            true,
+						MarkdownDescription: "Zone Code",
+					},
+					"name": schema.StringAttribute{
+						Computed:            true,
+						MarkdownDescription: "Zone Name",
+					},
+				},
+				MarkdownDescription: "Zone (datacentre)",
+			},
+			"specs": schema.SingleNestedAttribute{
+				Computed: true,
+				Attributes: map[string]schema.Attribute{
+					"disk_type": schema.StringAttribute{
+						Computed:            true,
+						MarkdownDescription: "Disk type",
+					},
+					"disk_size": schema.Int64Attribute{
+						Computed:            true,
+						MarkdownDescription: "Disk size in MB",
+					},
+					"cores": schema.Int64Attribute{
+						Computed:            true,
+						MarkdownDescription: "Number of virtual CPU cores",
+					},
+					"extra_cores": schema.Int64Attribute{
+						Computed:            true,
+						MarkdownDescription: "Number of CPU cores in addition to the ones provided by the base product (private cloud only)",
+					},
+					"ram": schema.Int64Attribute{
+						Computed:            true,
+						MarkdownDescription: "RAM size in MB",
+					},
+					"extra_ram": schema.Int64Attribute{
+						Computed:            true,
+						MarkdownDescription: "Amount of RAM (in MB) in addition to the RAM provided by the base product (private cloud only)",
+					},
+				},
+				MarkdownDescription: "Server specs",
+			},
+			"ssh_proxy": schema.SingleNestedAttribute{
+				Computed: true,
+				Attributes: map[string]schema.Attribute{
+					"hostname": schema.StringAttribute{
+						Computed:            true,
+						MarkdownDescription: "SSH proxy hostname",
+					},
+					"port": schema.Int64Attribute{
+						Computed:            true,
+						MarkdownDescription: "SSH proxy port",
+					},
+				},
+				MarkdownDescription: "SSH Proxy settings (for IPv4 access to IPv6-only servers)",
+			},
+			"vnc": schema.SingleNestedAttribute{
+				Computed: true,
+				Attributes: map[string]schema.Attribute{
+					"mode": schema.StringAttribute{
+						Computed:            true,
+						MarkdownDescription: "VNC mode",
+					},
+					"ipv4": schema.StringAttribute{
+						Computed:            true,
+						MarkdownDescription: "VNC IPv4 address",
+					},
+					"ipv6": schema.StringAttribute{
+						Computed:            true,
+						MarkdownDescription: "VNC IPv6 address",
+					},
+					"port": schema.Int64Attribute{
+						Computed:            true,
+						MarkdownDescription: "VNC port number",
+					},
+					"display": schema.Int64Attribute{
+						Computed:            true,
+						MarkdownDescription: "VNC display number",
+					},
+				},
+				MarkdownDescription: "VNC settings. The VNC password is not returned; use the [`mythicbeasts_vps_console` ephemeral resource](../ephemeral-resources/vps_console) to read it",
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *vpsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config vpsDataSourceModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data, err := d.client.VPS().Get(ctx, config.Identifier.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to read Mythic Beasts VPS",
+			"Could not read VPS "+config.Identifier.String()+": "+err.Error(),
+		)
+		return
+	}
+
+	server, diags := readServer(data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state := vpsDataSourceModel{
+		Identifier: config.Identifier,
+		Name:       server.Name,
+		Product:    server.Product,
+		Family:     server.Family,
+		HostServer: server.HostServer,
+		CPUMode:    server.CPUMode,
+		NetDevice:  server.NetDevice,
+		DiskBus:    server.DiskBus,
+		Tablet:     server.Tablet,
+		ISOImage:   server.ISOImage,
+		BootDevice: server.BootDevice,
+		Price:      server.Price,
+		Period:     server.Period,
+		Dormant:    server.Dormant,
+		IPv4:       server.IPv4,
+		IPv6:       server.IPv6,
+		Macs:       server.Macs,
+		Zone:       server.Zone,
+		Specs:      server.Specs,
+		SSHProxy:   server.SSHProxy,
+		VNC:        server.VNC,
+	}
+
+	// Set state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *vpsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*mythicbeasts.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *mythicbeasts.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
@@ -0,0 +1,50 @@
+// Copyright IBM Corp. 2021, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/compare"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+func TestAccVPSDataSource(t *testing.T) {
+	vpsIdentifier := testAccIdentifier("tfvd", 20)
+	resourceAddress := "mythicbeasts_vps." + vpsIdentifier
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: testAccVPSResourceConfig(vpsIdentifier, 10240, false, false, 0) + `
+data "mythicbeasts_vps" "test" {
+  identifier = ` + resourceAddress + `.identifier
+}
+`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.CompareValuePairs(
+						"data.mythicbeasts_vps.test", tfjsonpath.New("ipv6"),
+						resourceAddress, tfjsonpath.New("ipv6"),
+						compare.ValuesSame(),
+					),
+					statecheck.CompareValuePairs(
+						"data.mythicbeasts_vps.test", tfjsonpath.New("zone"),
+						resourceAddress, tfjsonpath.New("zone"),
+						compare.ValuesSame(),
+					),
+					statecheck.CompareValuePairs(
+						"data.mythicbeasts_vps.test", tfjsonpath.New("ssh_proxy"),
+						resourceAddress, tfjsonpath.New("ssh_proxy"),
+						compare.ValuesSame(),
+					),
+				},
+			},
+		},
+	})
+}
@@ -0,0 +1,288 @@
+// Copyright IBM Corp. 2021, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/paultibbetts/mythicbeasts-client-go"
+)
+
+const (
+	dnsRecordTypeA    = "A"
+	dnsRecordTypeAAAA = "AAAA"
+	dnsRecordTypePTR  = "PTR"
+)
+
+// VPSDNSRecordModel maps an entry of the dns_records attribute of the VPS resource.
+type VPSDNSRecordModel struct {
+	Type  types.String `tfsdk:"type"`
+	Name  types.String `tfsdk:"name"`
+	Value types.String `tfsdk:"value"`
+}
+
+var vpsDNSRecordAttrTypes = map[string]attr.Type{
+	"type":  types.StringType,
+	"name":  types.StringType,
+	"value": types.StringType,
+}
+
+var vpsDNSRecordType = types.ObjectType{AttrTypes: vpsDNSRecordAttrTypes}
+
+type vpsDNSRecord struct {
+	Type  string
+	Name  string
+	Value string
+}
+
+// vpsHostname returns the configured hostname, or the default the API
+// installs the server with.
+func vpsHostname(hostname types.String, identifier string) string {
+	if !hostname.IsNull() && !hostname.IsUnknown() && hostname.ValueString() != "" {
+		return hostname.ValueString()
+	}
+
+	return identifier + ".vs.mythic-beasts.com"
+}
+
+// desiredVPSDNSRecords returns the forward and reverse records for the
+// server's addresses, sorted so that plans are stable.
+func desiredVPSDNSRecords(hostname string, ipv4 []string, ipv6 []string, forward bool, reverse bool) []vpsDNSRecord {
+	records := []vpsDNSRecord{}
+
+	if forward {
+		for _, ip := range ipv4 {
+			records = append(records, vpsDNSRecord{Type: dnsRecordTypeA, Name: hostname, Value: ip})
+		}
+		for _, ip := range ipv6 {
+			records = append(records, vpsDNSRecord{Type: dnsRecordTypeAAAA, Name: hostname, Value: ip})
+		}
+	}
+
+	if reverse {
+		for _, ip := range ipv4 {
+			records = append(records, vpsDNSRecord{Type: dnsRecordTypePTR, Name: ip, Value: hostname})
+		}
+		for _, ip := range ipv6 {
+			records = append(records, vpsDNSRecord{Type: dnsRecordTypePTR, Name: ip, Value: hostname})
+		}
+	}
+
+	sortVPSDNSRecords(records)
+
+	return records
+}
+
+func sortVPSDNSRecords(records []vpsDNSRecord) {
+	sort.Slice(records, func(i, j int) bool {
+		if records[i].Type != records[j].Type {
+			return records[i].Type < records[j].Type
+		}
+		if records[i].Name != records[j].Name {
+			return records[i].Name < records[j].Name
+		}
+		return records[i].Value < records[j].Value
+	})
+}
+
+func equalVPSDNSRecords(a []vpsDNSRecord, b []vpsDNSRecord) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	a = append([]vpsDNSRecord(nil), a...)
+	b = append([]vpsDNSRecord(nil), b...)
+	sortVPSDNSRecords(a)
+	sortVPSDNSRecords(b)
+
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+func vpsDNSRecordsValue(records []vpsDNSRecord) (types.Set, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	values := make([]attr.Value, 0, len(records))
+	for _, record := range records {
+		value, d := types.ObjectValue(vpsDNSRecordAttrTypes, map[string]attr.Value{
+			"type":  types.StringValue(record.Type),
+			"name":  types.StringValue(record.Name),
+			"value": types.StringValue(record.Value),
+		})
+		diags = append(diags, d...)
+		values = append(values, value)
+	}
+
+	set, d := types.SetValue(vpsDNSRecordType, values)
+	diags = append(diags, d...)
+
+	return set, diags
+}
+
+func vpsDNSRecordsFromValue(ctx context.Context, value types.Set) ([]vpsDNSRecord, diag.Diagnostics) {
+	if value.IsNull() || value.IsUnknown() {
+		return nil, nil
+	}
+
+	var models []VPSDNSRecordModel
+	diags := value.ElementsAs(ctx, &models, false)
+
+	records := make([]vpsDNSRecord, 0, len(models))
+	for _, model := range models {
+		records = append(records, vpsDNSRecord{
+			Type:  model.Type.ValueString(),
+			Name:  model.Name.ValueString(),
+			Value: model.Value.ValueString(),
+		})
+	}
+
+	return records, diags
+}
+
+func stringsFromSet(ctx context.Context, value types.Set) ([]string, diag.Diagnostics) {
+	if value.IsNull() || value.IsUnknown() {
+		return nil, nil
+	}
+
+	var values []string
+	diags := value.ElementsAs(ctx, &values, false)
+
+	return values, diags
+}
+
+// setVPSDNSRecord creates or updates a record through the API.
+func setVPSDNSRecord(ctx context.Context, client *mythicbeasts.Client, identifier string, record vpsDNSRecord) error {
+	tflog.Info(ctx, fmt.Sprintf("setting %s record %s -> %s for VPS %s", record.Type, record.Name, record.Value, identifier))
+
+	if record.Type == dnsRecordTypePTR {
+		return client.VPS().SetReverseDNS(ctx, identifier, record.Name, record.Value)
+	}
+
+	return client.VPS().SetForwardDNS(ctx, identifier, record.Name, record.Value)
+}
+
+// deleteVPSDNSRecord removes a record through the API.
+func deleteVPSDNSRecord(ctx context.Context, client *mythicbeasts.Client, identifier string, record vpsDNSRecord) error {
+	tflog.Info(ctx, fmt.Sprintf("removing %s record %s -> %s for VPS %s", record.Type, record.Name, record.Value, identifier))
+
+	if record.Type == dnsRecordTypePTR {
+		return client.VPS().DeleteReverseDNS(ctx, identifier, record.Name)
+	}
+
+	return client.VPS().DeleteForwardDNS(ctx, identifier, record.Name, record.Value)
+}
+
+// vpsDNSRecordsForModel returns the records wanted for the addresses in
+// the model, given the hostname and the write-only DNS flags from the
+// configuration.
+func vpsDNSRecordsForModel(ctx context.Context, model VPSResourceModel, hostname types.String, forward types.Bool, reverse types.Bool) ([]vpsDNSRecord, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	ipv4, d := stringsFromSet(ctx, model.IPv4)
+	diags = append(diags, d...)
+	ipv6, d := stringsFromSet(ctx, model.IPv6)
+	diags = append(diags, d...)
+
+	name := vpsHostname(hostname, model.Identifier.ValueString())
+
+	return desiredVPSDNSRecords(name, ipv4, ipv6, forward.ValueBool(), reverse.ValueBool()), diags
+}
+
+// modifyPlanDNS plans the dns_records the provider will manage after a
+// change to the hostname, the DNS flags or the server's addresses.
+func (r *VPSResource) modifyPlanDNS(ctx context.Context, config VPSResourceModel, state VPSResourceModel, resp *resource.ModifyPlanResponse) {
+	var ipv4 types.Set
+	resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root("ipv4"), &ipv4)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if ipv4.IsUnknown() && (config.SetForwardDNS.ValueBool() || config.SetReverseDNS.ValueBool()) {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("dns_records"), types.SetUnknown(vpsDNSRecordType))...)
+		return
+	}
+
+	desired, diags := vpsDNSRecordsForModel(ctx, state, config.Hostname, config.SetForwardDNS, config.SetReverseDNS)
+	resp.Diagnostics.Append(diags...)
+
+	current, diags := vpsDNSRecordsFromValue(ctx, state.DNSRecords)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if equalVPSDNSRecords(current, desired) {
+		return
+	}
+
+	records, diags := vpsDNSRecordsValue(desired)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("dns_records"), records)...)
+}
+
+// reconcileVPSDNSRecords removes the records that are no longer wanted
+// before setting the wanted ones. A PTR record is left alone when its
+// address is being pointed somewhere else, or is no longer assigned to the
+// server, as reverse DNS is released along with the address.
+func reconcileVPSDNSRecords(ctx context.Context, client *mythicbeasts.Client, identifier string, addresses []string, current []vpsDNSRecord, desired []vpsDNSRecord) error {
+	assigned := make(map[string]bool, len(addresses))
+	for _, address := range addresses {
+		assigned[address] = true
+	}
+
+	wanted := make(map[vpsDNSRecord]bool, len(desired))
+	reversed := make(map[string]bool)
+	for _, record := range desired {
+		wanted[record] = true
+		if record.Type == dnsRecordTypePTR {
+			reversed[record.Name] = true
+		}
+	}
+
+	have := make(map[vpsDNSRecord]bool, len(current))
+	for _, record := range current {
+		have[record] = true
+
+		if wanted[record] {
+			continue
+		}
+
+		if record.Type == dnsRecordTypePTR && (reversed[record.Name] || !assigned[record.Name]) {
+			continue
+		}
+
+		if err := deleteVPSDNSRecord(ctx, client, identifier, record); err != nil {
+			return fmt.Errorf("removing %s record %s: %w", record.Type, record.Name, err)
+		}
+	}
+
+	for _, record := range desired {
+		if have[record] {
+			continue
+		}
+
+		if err := setVPSDNSRecord(ctx, client, identifier, record); err != nil {
+			return fmt.Errorf("setting %s record %s: %w", record.Type, record.Name, err)
+		}
+	}
+
+	return nil
+}
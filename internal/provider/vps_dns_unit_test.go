@@ -0,0 +1,58 @@
+// Copyright IBM Corp. 2021, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestDesiredVPSDNSRecords(t *testing.T) {
+	got := desiredVPSDNSRecords("vps.example.com", []string{"203.0.113.1"}, []string{"2a00:1098::1"}, true, true)
+	want := []vpsDNSRecord{
+		{Type: dnsRecordTypeA, Name: "vps.example.com", Value: "203.0.113.1"},
+		{Type: dnsRecordTypeAAAA, Name: "vps.example.com", Value: "2a00:1098::1"},
+		{Type: dnsRecordTypePTR, Name: "203.0.113.1", Value: "vps.example.com"},
+		{Type: dnsRecordTypePTR, Name: "2a00:1098::1", Value: "vps.example.com"},
+	}
+
+	if !equalVPSDNSRecords(got, want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+
+	got = desiredVPSDNSRecords("vps.example.com", []string{"203.0.113.1"}, []string{"2a00:1098::1"}, false, false)
+	if len(got) != 0 {
+		t.Fatalf("expected no records without forward or reverse DNS, got %v", got)
+	}
+}
+
+func TestVPSHostnameDefault(t *testing.T) {
+	if got := vpsHostname(types.StringNull(), "example"); got != "example.vs.mythic-beasts.com" {
+		t.Fatalf("expected default hostname, got %q", got)
+	}
+
+	if got := vpsHostname(types.StringValue("vps.example.com"), "example"); got != "vps.example.com" {
+		t.Fatalf("expected configured hostname, got %q", got)
+	}
+}
+
+func TestVPSDNSRecordsValueRoundTrip(t *testing.T) {
+	records := desiredVPSDNSRecords("vps.example.com", nil, []string{"2a00:1098::1"}, true, true)
+
+	value, diags := vpsDNSRecordsValue(records)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	got, diags := vpsDNSRecordsFromValue(context.Background(), value)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	if !equalVPSDNSRecords(got, records) {
+		t.Fatalf("expected %v, got %v", records, got)
+	}
+}
@@ -0,0 +1,181 @@
+// Copyright IBM Corp. 2021, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/paultibbetts/mythicbeasts-client-go"
+	mbVPS "github.com/paultibbetts/mythicbeasts-client-go/vps"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &VPSISOImagesDataSource{}
+	_ datasource.DataSourceWithConfigure = &VPSISOImagesDataSource{}
+)
+
+// NewVPSISOImagesDataSource is a helper function to simplify the provider implementation.
+func NewVPSISOImagesDataSource() datasource.DataSource {
+	return &VPSISOImagesDataSource{}
+}
+
+// VPSISOImagesDataSource is the data source implementation.
+type VPSISOImagesDataSource struct {
+	client *mythicbeasts.Client
+}
+
+type VPSISOImagesDataSourceModel struct {
+	Architecture types.String       `tfsdk:"architecture"`
+	NameRegex    types.String       `tfsdk:"name_regex"`
+	ISOImages    []VPSISOImageModel `tfsdk:"iso_images"`
+}
+
+type VPSISOImageModel struct {
+	Name         types.String `tfsdk:"name"`
+	Description  types.String `tfsdk:"description"`
+	Architecture types.String `tfsdk:"architecture"`
+}
+
+// Metadata returns the data source type name.
+func (d *VPSISOImagesDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_vps_iso_images"
+}
+
+// Schema defines the schema for the data source.
+func (d *VPSISOImagesDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Returns ISO images available for the virtual CD drive of a VPS. Use ISO image names from this data source when setting `iso_image` on [`mythicbeasts_vps` resource](../resources/vps).",
+		Attributes: map[string]schema.Attribute{
+			"architecture": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only return ISO images for this architecture, such as `x86_64`",
+			},
+			"name_regex": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only return ISO images whose name matches this regular expression",
+			},
+			"iso_images": schema.SetNestedAttribute{
+				Computed: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Computed: true,
+						},
+						"description": schema.StringAttribute{
+							Computed: true,
+						},
+						"architecture": schema.StringAttribute{
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *VPSISOImagesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config VPSISOImagesDataSourceModel // for input
+	configDiags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(configDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var nameRegex *regexp.Regexp
+	if !config.NameRegex.IsNull() {
+		var err error
+		nameRegex, err = regexp.Compile(config.NameRegex.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("name_regex"),
+				"Invalid name_regex",
+				err.Error(),
+			)
+			return
+		}
+	}
+
+	state := VPSISOImagesDataSourceModel{
+		Architecture: config.Architecture,
+		NameRegex:    config.NameRegex,
+		ISOImages:    []VPSISOImageModel{},
+	}
+
+	VPSISOImages, err := d.client.VPS().GetISOImages(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to read Mythic Beasts VPS ISO images",
+			err.Error(),
+		)
+		return
+	}
+
+	// Map response body to model
+	for _, image := range filterVPSISOImages(VPSISOImages, config.Architecture.ValueString(), nameRegex) {
+		VPSISOImagesState := VPSISOImageModel{
+			Name:         types.StringValue(image.Name),
+			Description:  types.StringValue(image.Description),
+			Architecture: types.StringValue(image.Architecture),
+		}
+
+		state.ISOImages = append(state.ISOImages, VPSISOImagesState)
+	}
+
+	// Set state
+	diags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// filterVPSISOImages returns the images matching the architecture and
+// name regular expression; empty filters match every image.
+func filterVPSISOImages(images []mbVPS.ISOImage, architecture string, nameRegex *regexp.Regexp) []mbVPS.ISOImage {
+	filtered := []mbVPS.ISOImage{}
+	for _, image := range images {
+		if architecture != "" && !strings.EqualFold(image.Architecture, architecture) {
+			continue
+		}
+
+		if nameRegex != nil && !nameRegex.MatchString(image.Name) {
+			continue
+		}
+
+		filtered = append(filtered, image)
+	}
+
+	return filtered
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *VPSISOImagesDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*mythicbeasts.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *mythicbeasts.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
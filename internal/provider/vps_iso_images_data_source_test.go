@@ -0,0 +1,52 @@
+// Copyright IBM Corp. 2021, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+func TestAccVPSISOImagesDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: testAccVPSISOImagesDataSourceConfig,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"data.mythicbeasts_vps_iso_images.all",
+						tfjsonpath.New("iso_images"),
+						knownvalue.SetPartial([]knownvalue.Check{
+							knownvalue.ObjectPartial(map[string]knownvalue.Check{
+								"architecture": knownvalue.NotNull(),
+								"description":  knownvalue.NotNull(),
+								"name":         knownvalue.NotNull(),
+							}),
+						}),
+					),
+					statecheck.ExpectKnownValue(
+						"data.mythicbeasts_vps_iso_images.none",
+						tfjsonpath.New("iso_images"),
+						knownvalue.SetSizeExact(0),
+					),
+				},
+			},
+		},
+	})
+}
+
+const testAccVPSISOImagesDataSourceConfig = `
+data "mythicbeasts_vps_iso_images" "all" {}
+
+data "mythicbeasts_vps_iso_images" "none" {
+  name_regex = "^no-such-iso-image$"
+}
+`
@@ -0,0 +1,32 @@
+// Copyright IBM Corp. 2021, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	mbVPS "github.com/paultibbetts/mythicbeasts-client-go/vps"
+)
+
+func TestFilterVPSISOImages(t *testing.T) {
+	images := []mbVPS.ISOImage{
+		{Name: "debian-13-amd64", Architecture: "x86_64"},
+		{Name: "debian-13-arm64", Architecture: "aarch64"},
+		{Name: "ubuntu-24.04-amd64", Architecture: "x86_64"},
+	}
+
+	if got := filterVPSISOImages(images, "", nil); len(got) != len(images) {
+		t.Fatalf("expected every image without filters, got %v", got)
+	}
+
+	got := filterVPSISOImages(images, "X86_64", regexp.MustCompile("^debian-"))
+	if len(got) != 1 || got[0].Name != "debian-13-amd64" {
+		t.Fatalf("expected only debian-13-amd64, got %v", got)
+	}
+
+	if got := filterVPSISOImages(images, "riscv64", nil); len(got) != 0 {
+		t.Fatalf("expected no images, got %v", got)
+	}
+}
@@ -0,0 +1,161 @@
+// Copyright IBM Corp. 2021, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/action/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/paultibbetts/mythicbeasts-client-go"
+	mbVPS "github.com/paultibbetts/mythicbeasts-client-go/vps"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ action.Action                   = &VPSPowerAction{}
+	_ action.ActionWithConfigure      = &VPSPowerAction{}
+	_ action.ActionWithValidateConfig = &VPSPowerAction{}
+)
+
+// vpsPowerActions maps the power values of the action to the API.
+var vpsPowerActions = map[string]mbVPS.PowerAction{
+	"on":       mbVPS.PowerActionOn,
+	"off":      mbVPS.PowerActionOff,
+	"shutdown": mbVPS.PowerActionShutdown,
+	"reboot":   mbVPS.PowerActionReboot,
+	"reset":    mbVPS.PowerActionReset,
+}
+
+// NewVPSPowerAction is a helper function to simplify the provider implementation.
+func NewVPSPowerAction() action.Action {
+	return &VPSPowerAction{}
+}
+
+// VPSPowerAction is the action implementation.
+type VPSPowerAction struct {
+	client *mythicbeasts.Client
+}
+
+// VPSPowerActionModel maps the action schema data.
+type VPSPowerActionModel struct {
+	Identifier  types.String `tfsdk:"identifier"`
+	Power       types.String `tfsdk:"power"`
+	GracePeriod types.Int64  `tfsdk:"grace_period"`
+}
+
+// Metadata returns the action type name.
+func (a *VPSPowerAction) Metadata(_ context.Context, req action.MetadataRequest, resp *action.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_vps_power"
+}
+
+// Schema defines the schema for the action.
+func (a *VPSPowerAction) Schema(_ context.Context, _ action.SchemaRequest, resp *action.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Changes the power state of a [`mythicbeasts_vps` resource](../resources/vps).\n\n" +
+			"Run it with `terraform apply -invoke=action.mythicbeasts_vps_power.<name>` or from an `action_trigger` in a resource's `lifecycle` block.",
+		Attributes: map[string]schema.Attribute{
+			"identifier": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Identifier of the server",
+			},
+			"power": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Power operation; one of `on`, `off`, `shutdown`, `reboot` or `reset`\n\n`shutdown` asks the operating system to shut down, while `off` and `reset` act like pulling the power cable.",
+				Validators: []validator.String{
+					stringvalidator.OneOf("on", "off", "shutdown", "reboot", "reset"),
+				},
+			},
+			"grace_period": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: "Seconds to wait for the operating system to shut down before the server is powered off; only valid with `shutdown`",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the action.
+func (a *VPSPowerAction) Configure(_ context.Context, req action.ConfigureRequest, resp *action.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*mythicbeasts.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Action Configure Type",
+			fmt.Sprintf("Expected *mythicbeasts.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	a.client = client
+}
+
+// ValidateConfig rejects a grace period for anything but a shutdown.
+func (a *VPSPowerAction) ValidateConfig(ctx context.Context, req action.ValidateConfigRequest, resp *action.ValidateConfigResponse) {
+	var config VPSPowerActionModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if config.GracePeriod.IsNull() || config.Power.IsUnknown() || config.Power.ValueString() == "shutdown" {
+		return
+	}
+
+	resp.Diagnostics.AddAttributeError(
+		path.Root("grace_period"),
+		"Invalid grace_period",
+		fmt.Sprintf("grace_period can only be set when power is \"shutdown\", not %q.", config.Power.ValueString()),
+	)
+}
+
+// Invoke changes the power state of the server.
+func (a *VPSPowerAction) Invoke(ctx context.Context, req action.InvokeRequest, resp *action.InvokeResponse) {
+	var config VPSPowerActionModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	identifier := config.Identifier.ValueString()
+	power := config.Power.ValueString()
+
+	resp.SendProgress(action.InvokeProgressEvent{
+		Message: fmt.Sprintf("Sending %s to VPS %s", power, identifier),
+	})
+
+	var server mbVPS.Server
+	var err error
+	if power == "shutdown" {
+		server, err = a.client.VPS().ShutdownWithGrace(ctx, identifier, config.GracePeriod.ValueInt64())
+	} else {
+		server, err = a.client.VPS().SetPower(ctx, identifier, vpsPowerActions[power])
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error changing VPS power state",
+			fmt.Sprintf("Could not %s VPS %s, unexpected error: %s", power, identifier, err.Error()),
+		)
+		return
+	}
+
+	resp.SendProgress(action.InvokeProgressEvent{
+		Message: fmt.Sprintf("VPS %s is %s", identifier, server.Status),
+	})
+}
@@ -0,0 +1,115 @@
+// Copyright IBM Corp. 2021, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+	"github.com/paultibbetts/mythicbeasts-client-go"
+)
+
+func TestAccVPSPowerAction(t *testing.T) {
+	identifier := testAccIdentifier("tfpwr", 20)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_14_0),
+		},
+		Steps: []resource.TestStep{
+			// A grace period is only valid for a shutdown
+			{
+				Config:      testAccVPSPowerActionConfig(identifier, "reboot", "grace_period = 30"),
+				ExpectError: regexp.MustCompile("grace_period can only be set"),
+			},
+			// Reboot the server once it has been created
+			{
+				Config: testAccVPSPowerActionConfig(identifier, "reboot", ""),
+				Check:  testAccCheckVPSStatus(identifier, "running"),
+			},
+			// Power the server off
+			{
+				Config: testAccVPSPowerActionConfig(identifier, "off", ""),
+				Check:  testAccCheckVPSStatus(identifier, "stopped"),
+			},
+			// Power the server back on
+			{
+				Config: testAccVPSPowerActionConfig(identifier, "on", ""),
+				Check:  testAccCheckVPSStatus(identifier, "running"),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccVPSPowerActionConfig(identifier string, power string, extra string) string {
+	return fmt.Sprintf(`
+resource "mythicbeasts_vps" %[1]q {
+  identifier     = %[1]q
+  name           = %[1]q
+  disk_size      = 10240
+  image          = "cloudinit-ubuntu-noble.raw.gz"
+  product        = "VPSX4"
+  create_in_zone = "uk"
+  ssh_keys       = "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIPfx70ArvHPF+9U3GgKgNEAWkXSyZMun83sn9582Pl4e code@paultibbetts.uk"
+
+  wait_for = {
+    status = "running"
+  }
+}
+
+action "mythicbeasts_vps_power" "test" {
+  config {
+    identifier = mythicbeasts_vps.%[1]s.identifier
+    power      = %[2]q
+    %[3]s
+  }
+}
+
+resource "terraform_data" "trigger" {
+  input = "${mythicbeasts_vps.%[1]s.identifier}-%[2]s"
+
+  lifecycle {
+    action_trigger {
+      events  = [after_create, after_update]
+      actions = [action.mythicbeasts_vps_power.test]
+    }
+  }
+}
+`, identifier, power, extra)
+}
+
+// testAccCheckVPSStatus reads the server through the API until it reports
+// the wanted status, as power changes take a moment to apply.
+func testAccCheckVPSStatus(identifier string, want string) resource.TestCheckFunc {
+	return func(_ *terraform.State) error {
+		client, err := mythicbeasts.NewClient(os.Getenv("MYTHICBEASTS_KEYID"), os.Getenv("MYTHICBEASTS_SECRET"))
+		if err != nil {
+			return fmt.Errorf("unable to create client: %w", err)
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+		defer cancel()
+
+		getStatus := func(ctx context.Context) (string, error) {
+			server, err := client.VPS().Get(ctx, identifier)
+			if err != nil {
+				return "", err
+			}
+
+			return server.Status, nil
+		}
+
+		return waitForStatus(ctx, getStatus, want, vpsWaitPollInterval)
+	}
+}
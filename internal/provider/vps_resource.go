@@ -9,7 +9,10 @@ import (
 	"errors"
 	"fmt"
 	"regexp"
+	"slices"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
@@ -29,6 +32,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/paultibbetts/mythicbeasts-client-go"
 	mbVPS "github.com/paultibbetts/mythicbeasts-client-go/vps"
@@ -39,6 +43,7 @@ var (
 	_ resource.Resource                = &VPSResource{}
 	_ resource.ResourceWithConfigure   = &VPSResource{}
 	_ resource.ResourceWithImportState = &VPSResource{}
+	_ resource.ResourceWithModifyPlan  = &VPSResource{}
 )
 
 // NewVPSResource is a helper function to simplify the provider implementation.
@@ -53,19 +58,22 @@ type VPSResource struct {
 
 // VPSResourceModel maps the resource schema data.
 type VPSResourceModel struct {
-	Identifier     types.String `tfsdk:"identifier"`
-	Product        types.String `tfsdk:"product"`
-	Name           types.String `tfsdk:"name"`
-	Hostname       types.String `tfsdk:"hostname"`
-	SetForwardDNS  types.Bool   `tfsdk:"set_forward_dns"`
-	SetReverseDNS  types.Bool   `tfsdk:"set_reverse_dns"`
-	UserData       types.String `tfsdk:"user_data"`
-	UserDataString types.String `tfsdk:"user_data_string"`
-	IPv4Enabled    types.Bool   `tfsdk:"ipv4_enabled"`
-	DiskSize       types.Int64  `tfsdk:"disk_size"`
-	Image          types.String `tfsdk:"image"`
-	SSHKeys        types.String `tfsdk:"ssh_keys"`
-	CreateInZone   types.String `tfsdk:"create_in_zone"`
+	Identifier           types.String `tfsdk:"identifier"`
+	Product              types.String `tfsdk:"product"`
+	Name                 types.String `tfsdk:"name"`
+	Hostname             types.String `tfsdk:"hostname"`
+	SetForwardDNS        types.Bool   `tfsdk:"set_forward_dns"`
+	SetReverseDNS        types.Bool   `tfsdk:"set_reverse_dns"`
+	UserData             types.String `tfsdk:"user_data"`
+	UserDataString       types.String `tfsdk:"user_data_string"`
+	IPv4Enabled          types.Bool   `tfsdk:"ipv4_enabled"`
+	DiskSize             types.Int64  `tfsdk:"disk_size"`
+	Image                types.String `tfsdk:"image"`
+	SSHKeys              types.String `tfsdk:"ssh_keys"`
+	SSHKeysVersion       types.Int64  `tfsdk:"ssh_keys_version"`
+	CreateInZone         types.String `tfsdk:"create_in_zone"`
+	VNCPasswordWO        types.String `tfsdk:"vnc_password_wo"`
+	VNCPasswordWOVersion types.Int64  `tfsdk:"vnc_password_wo_version"`
 
 	HostServer types.String  `tfsdk:"host_server"`
 	ISOImage   types.String  `tfsdk:"iso_image"`
@@ -85,6 +93,8 @@ type VPSResourceModel struct {
 	Macs       types.List    `tfsdk:"macs"`
 	SSHProxy   types.Object  `tfsdk:"ssh_proxy"`
 	VNC        types.Object  `tfsdk:"vnc"`
+	WaitFor    types.Object  `tfsdk:"wait_for"`
+	DNSRecords types.Set     `tfsdk:"dns_records"`
 }
 
 type ZoneModel struct {
@@ -102,12 +112,11 @@ type SpecsModel struct {
 }
 
 type VNCModel struct {
-	Mode     types.String `tfsdk:"mode"`
-	Password types.String `tfsdk:"password"`
-	IPv4     types.String `tfsdk:"ipv4"`
-	IPv6     types.String `tfsdk:"ipv6"`
-	Port     types.Int64  `tfsdk:"port"`
-	Display  types.Int64  `tfsdk:"display"`
+	Mode    types.String `tfsdk:"mode"`
+	IPv4    types.String `tfsdk:"ipv4"`
+	IPv6    types.String `tfsdk:"ipv6"`
+	Port    types.Int64  `tfsdk:"port"`
+	Display types.Int64  `tfsdk:"display"`
 }
 
 // Metadata returns the resource type name.
@@ -119,7 +128,7 @@ func (r *VPSResource) Metadata(_ context.Context, req resource.MetadataRequest,
 func (r *VPSResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		MarkdownDescription: "Manages a Mythic Beasts VPS.\n\n" +
-			"In-place updates are supported for `product`, `name`, `disk_size`, `specs.extra_cores`, `specs.extra_ram`, `iso_image`, `boot_device`, `cpu_mode`, `net_device`, `disk_bus`, and `tablet`.\n\n" +
+			"In-place updates are supported for `product`, `name`, `hostname`, `set_forward_dns`, `set_reverse_dns`, `ipv4_enabled`, `disk_size`, `specs.extra_cores`, `specs.extra_ram`, `iso_image`, `boot_device`, `cpu_mode`, `net_device`, `disk_bus`, and `tablet`.\n\n" +
 			"The Mythic Beasts API requires the VPS to be powered off before changing `iso_image`, `boot_device`, `cpu_mode`, `net_device`, `disk_bus`, or `tablet`. The provider automatically powers off a running VPS before applying these changes and powers it back on afterwards.",
 		Attributes: map[string]schema.Attribute{
 			"identifier": schema.StringAttribute{
@@ -158,28 +167,50 @@ func (r *VPSResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *
 			},
 			"hostname": schema.StringAttribute{
 				Optional:            true,
-				PlanModifiers:       []planmodifier.String{stringplanmodifier.RequiresReplace()},
-				MarkdownDescription: "Hostname the new server should be installed with\nDefault: `{identifier}.vs.mythic-beasts.com`",
+				MarkdownDescription: "Hostname the new server should be installed with\nDefault: `{identifier}.vs.mythic-beasts.com`\n\nChanging this on an existing server updates the records in `dns_records`; the hostname inside the operating system is not changed.",
 			},
 			"set_forward_dns": schema.BoolAttribute{
 				Optional:            true,
 				WriteOnly:           true,
-				MarkdownDescription: "Whether to automatically add A/AAAA records for the server's IP addresses to the selected hostname\nDefault: `false`",
+				MarkdownDescription: "Whether to automatically add A/AAAA records for the server's IP addresses to the selected hostname\nDefault: `false`\n\nChanging this on an existing server adds or removes the records in place.",
 			},
 			"set_reverse_dns": schema.BoolAttribute{
 				Optional:            true,
 				WriteOnly:           true,
-				MarkdownDescription: "Whether to automatically set reverse DNS for the server's IP addresses to the selected hostname\nDefault: `false`",
+				MarkdownDescription: "Whether to automatically set reverse DNS for the server's IP addresses to the selected hostname\nDefault: `false`\n\nChanging this on an existing server adds or removes the records in place.",
+			},
+			"dns_records": schema.SetNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "DNS records set by the provider for the server's addresses, from `set_forward_dns` and `set_reverse_dns`; they are removed when the server is destroyed",
+				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.UseStateForUnknown(),
+				},
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"type": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Record type; `A`, `AAAA` or `PTR`",
+						},
+						"name": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Hostname for `A` and `AAAA` records, or the IP address for `PTR` records",
+						},
+						"value": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "IP address for `A` and `AAAA` records, or the hostname for `PTR` records",
+						},
+					},
+				},
 			},
 			"ipv4_enabled": schema.BoolAttribute{
 				Optional:            true,
 				WriteOnly:           true,
-				MarkdownDescription: "Whether or not to allocate an IPv4 address for this server; an IPv6 address will always be allocated; IPv4 is a chargeable option; see the [`mythicbeasts_vps_pricing` data source](../data-sources/vps_pricing) for the price",
+				MarkdownDescription: "Whether or not to allocate an IPv4 address for this server; an IPv6 address will always be allocated; IPv4 is a chargeable option; see the [`mythicbeasts_vps_pricing` data source](../data-sources/vps_pricing) for the price\n\nChanging this on an existing server allocates or releases IPv4 addresses in place. Leaving it unset keeps whatever the server currently has.",
 			},
 			"disk_size": schema.Int64Attribute{
 				Required:            true,
 				WriteOnly:           true,
-				MarkdownDescription: "Disk size, in MB; see the [`mythicbeasts_vps_disk_sizes` data source](../data-sources/vps_disk_sizes) for valid values",
+				MarkdownDescription: "Disk size, in MB; see the [`mythicbeasts_vps_disk_sizes` data source](../data-sources/vps_disk_sizes) for valid values\n\nThe disk of an existing server can be grown in place but not shrunk; a smaller size is rejected at plan time.",
 				Validators: []validator.Int64{
 					int64validator.AtLeast(1),
 				},
@@ -202,12 +233,39 @@ func (r *VPSResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *
 			"ssh_keys": schema.StringAttribute{
 				Required:            true,
 				WriteOnly:           true,
-				MarkdownDescription: "Public SSH key(s) to be added to /root/.ssh/authorized_keys on server",
+				MarkdownDescription: "Public SSH key(s) to be added to /root/.ssh/authorized_keys on server\n\nThe keys are only installed when the server is created; change `ssh_keys_version` to replace the server with new keys.",
+			},
+			"ssh_keys_version": schema.Int64Attribute{
+				Optional: true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplaceIf(
+						requiresReplaceIfVersionChanged,
+						"Changing the version replaces the server, unless the version is being set for the first time or removed.",
+						"Changing the version replaces the server, unless the version is being set for the first time or removed.",
+					),
+				},
+				MarkdownDescription: "Version of `ssh_keys`. Changing this value replaces the server so that it is installed with the current `ssh_keys`. Setting it on an existing server for the first time, or removing it, does not replace it.",
 			},
 			"create_in_zone": schema.StringAttribute{
 				Optional:            true,
 				WriteOnly:           true,
-				MarkdownDescription: "Zone (datacentre) code; see the [`mythicbeasts_vps_zones` data source](../data-sources/vps_zones) for valid values",
+				MarkdownDescription: "Zone (datacentre) code; see the [`mythicbeasts_vps_zones` data source](../data-sources/vps_zones) for valid values\n\nIf the server is not in this zone, or in a zone within it, the server is replaced.",
+			},
+			"vnc_password_wo": schema.StringAttribute{
+				Optional:  true,
+				WriteOnly: true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+					stringvalidator.AlsoRequires(path.MatchRoot("vnc_password_wo_version")),
+				},
+				MarkdownDescription: "VNC console password. The password is never stored in state; use the [`mythicbeasts_vps_console` ephemeral resource](../ephemeral-resources/vps_console) to read it",
+			},
+			"vnc_password_wo_version": schema.Int64Attribute{
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AlsoRequires(path.MatchRoot("vnc_password_wo")),
+				},
+				MarkdownDescription: "Version of `vnc_password_wo`. The VNC password is only sent to the API when this value changes",
 			},
 			"host_server": schema.StringAttribute{
 				Computed: true,
@@ -216,7 +274,7 @@ func (r *VPSResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *
 					stringplanmodifier.UseStateForUnknown(),
 					stringplanmodifier.RequiresReplace(),
 				},
-				MarkdownDescription: "Name of private cloud host server to provision on; see the [`mythicbeasts_vps_hosts` data source](../data-sources/vps_hosts) for valid values",
+				MarkdownDescription: "Name of private cloud host server to provision on; see the [`mythicbeasts_vps_hosts` data source](../data-sources/vps_hosts) for valid values\n\nThe plan fails if the RAM and disk of every server planned on the host in the same run do not fit in the host's free capacity.",
 			},
 			"cpu_mode": schema.StringAttribute{
 				Computed: true,
@@ -266,7 +324,7 @@ func (r *VPSResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.UseStateForUnknown(),
 				},
-				MarkdownDescription: "ISO image currently in virtual CD drive. Set to `null` to remove.\n\nChanging this setting via the API requires the VPS to be powered off.",
+				MarkdownDescription: "ISO image currently in virtual CD drive. Set to `null` to remove; see the [`mythicbeasts_vps_iso_images` data source](../data-sources/vps_iso_images) for valid values\n\nChanging this setting via the API requires the VPS to be powered off. The value is checked at plan time, before the VPS is powered off.",
 			},
 			"family": schema.StringAttribute{
 				Computed: true,
@@ -308,7 +366,7 @@ func (r *VPSResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *
 				PlanModifiers: []planmodifier.Set{
 					setplanmodifier.UseStateForUnknown(),
 				},
-				MarkdownDescription: "List of IPv4 addresses, if IPv4 was enabled during creation",
+				MarkdownDescription: "List of IPv4 addresses, if IPv4 is enabled",
 			},
 			"ipv6": schema.SetAttribute{
 				Computed:    true,
@@ -423,12 +481,6 @@ func (r *VPSResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *
 						Optional:            true,
 						MarkdownDescription: "VNC mode",
 					},
-					"password": schema.StringAttribute{
-						Computed:            true,
-						Optional:            true,
-						Sensitive:           true,
-						MarkdownDescription: "VNC password",
-					},
 					"ipv4": schema.StringAttribute{
 						Computed:            true,
 						MarkdownDescription: "VNC IPv4 address",
@@ -451,6 +503,40 @@ func (r *VPSResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *
 				},
 				MarkdownDescription: "VNC settings",
 			},
+			"wait_for": schema.SingleNestedAttribute{
+				Optional: true,
+				Attributes: map[string]schema.Attribute{
+					"status": schema.StringAttribute{
+						Optional: true,
+						Computed: true,
+						Default:  stringdefault.StaticString(defaultVPSWaitForStatus),
+						Validators: []validator.String{
+							stringvalidator.RegexMatches(
+								regexp.MustCompile(`^\S+$`),
+								"must not be empty or contain whitespace",
+							),
+						},
+						MarkdownDescription: "Server status to wait for\nDefault: `" + defaultVPSWaitForStatus + "`",
+					},
+					"tcp_port": schema.Int64Attribute{
+						Optional: true,
+						Validators: []validator.Int64{
+							int64validator.Between(1, 65535),
+						},
+						MarkdownDescription: "TCP port that must accept connections before creation completes. The provider tries each of the server's IPv6 addresses and, for port `22`, the SSH proxy",
+					},
+					"timeout": schema.StringAttribute{
+						Optional: true,
+						Computed: true,
+						Default:  stringdefault.StaticString(defaultVPSWaitForTimeout),
+						Validators: []validator.String{
+							Duration(),
+						},
+						MarkdownDescription: "How long to wait, as a duration such as `30s` or `10m`\nDefault: `" + defaultVPSWaitForTimeout + "`",
+					},
+				},
+				MarkdownDescription: "Wait for the server to be ready before creation completes. If the wait times out the server is kept in state and marked as tainted.",
+			},
 		},
 	}
 }
@@ -566,7 +652,9 @@ func (r *VPSResource) Create(ctx context.Context, req resource.CreateRequest, re
 		}
 	}
 
+	unlockHost := r.lockHost(VPS.HostServer, identifier)
 	data, err := r.client.VPS().Create(ctx, identifier, VPS)
+	unlockHost()
 	if err != nil {
 		var identifierConflictErr *mbVPS.ErrIdentifierConflict
 		if errors.As(err, &identifierConflictErr) {
@@ -586,6 +674,60 @@ func (r *VPSResource) Create(ctx context.Context, req resource.CreateRequest, re
 
 	server, d := readServer(data)
 	resp.Diagnostics.Append(d...)
+	server.keepConfiguredValues(plan)
+
+	// The API sets the records during creation, so track what it was asked
+	// for so that they can be changed and removed later.
+	dnsRecords, d := vpsDNSRecordsForModel(ctx, *server, plan.Hostname, config.SetForwardDNS, config.SetReverseDNS)
+	resp.Diagnostics.Append(d...)
+	server.DNSRecords, d = vpsDNSRecordsValue(dnsRecords)
+	resp.Diagnostics.Append(d...)
+
+	if !config.VNCPasswordWO.IsNull() && !config.VNCPasswordWO.IsUnknown() {
+		vncUpdate := mbVPS.NewUpdateRequest()
+		vncUpdate.SetVNCPassword(config.VNCPasswordWO.ValueString())
+
+		if _, err := r.client.VPS().Update(ctx, identifier, vncUpdate); err != nil {
+			resp.Diagnostics.AddError(
+				"Error setting VPS VNC password",
+				fmt.Sprintf("VPS %s was created but setting the VNC password failed: %s", identifier, err.Error()),
+			)
+		}
+	}
+
+	// Save the server before waiting so that it is tracked, and tainted,
+	// if it never becomes ready.
+	diags = resp.State.Set(ctx, *server)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if plan.WaitFor.IsNull() || plan.WaitFor.IsUnknown() {
+		return
+	}
+
+	var waitFor VPSWaitForModel
+	diags = plan.WaitFor.As(ctx, &waitFor, basetypes.ObjectAsOptions{})
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ready, err := r.waitForServer(ctx, identifier, waitFor)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error waiting for VPS",
+			fmt.Sprintf("VPS %s was created but did not become ready: %s", identifier, err.Error()),
+		)
+		return
+	}
+
+	server, d = readServer(ready)
+	resp.Diagnostics.Append(d...)
+	server.keepConfiguredValues(plan)
+	server.DNSRecords, d = vpsDNSRecordsValue(dnsRecords)
+	resp.Diagnostics.Append(d...)
 
 	diags = resp.State.Set(ctx, *server)
 	resp.Diagnostics.Append(diags...)
@@ -594,6 +736,81 @@ func (r *VPSResource) Create(ctx context.Context, req resource.CreateRequest, re
 	}
 }
 
+// waitForServer blocks until the server reaches the wanted status and,
+// if a TCP port is set, accepts connections on that port.
+func (r *VPSResource) waitForServer(ctx context.Context, identifier string, waitFor VPSWaitForModel) (mbVPS.Server, error) {
+	status := defaultVPSWaitForStatus
+	if !waitFor.Status.IsNull() && !waitFor.Status.IsUnknown() {
+		status = waitFor.Status.ValueString()
+	}
+
+	timeout := defaultVPSWaitForTimeout
+	if !waitFor.Timeout.IsNull() && !waitFor.Timeout.IsUnknown() {
+		timeout = waitFor.Timeout.ValueString()
+	}
+
+	duration, err := time.ParseDuration(timeout)
+	if err != nil {
+		return mbVPS.Server{}, fmt.Errorf("invalid timeout %q: %w", timeout, err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, duration)
+	defer cancel()
+
+	var server mbVPS.Server
+	getStatus := func(ctx context.Context) (string, error) {
+		var err error
+		server, err = r.client.VPS().Get(ctx, identifier)
+		if err != nil {
+			return "", err
+		}
+		return server.Status, nil
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("waiting for VPS %s to be %s", identifier, status))
+
+	if err := waitForStatus(ctx, getStatus, status, vpsWaitPollInterval); err != nil {
+		return server, err
+	}
+
+	if waitFor.TCPPort.IsNull() || waitFor.TCPPort.IsUnknown() {
+		return server, nil
+	}
+
+	addresses := vpsTCPAddresses(server.IPv6, waitFor.TCPPort.ValueInt64(), server.SSHProxy.Hostname, server.SSHProxy.Port)
+
+	tflog.Info(ctx, fmt.Sprintf("waiting for VPS %s to accept TCP connections", identifier), map[string]interface{}{
+		"addresses": addresses,
+	})
+
+	address, err := waitForTCP(ctx, addresses, vpsWaitPollInterval)
+	if err != nil {
+		return server, err
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("VPS %s accepted a TCP connection on %s", identifier, address))
+
+	return server, nil
+}
+
+// requiresReplaceIfVersionChanged replaces the server when a version
+// attribute changes from one value to another, but not when it is first set
+// on an existing server or removed from the configuration.
+func requiresReplaceIfVersionChanged(_ context.Context, req planmodifier.Int64Request, resp *int64planmodifier.RequiresReplaceIfFuncResponse) {
+	resp.RequiresReplace = !req.StateValue.IsNull() && !req.PlanValue.IsNull()
+}
+
+// keepConfiguredValues copies attributes that only exist in the
+// configuration or are tracked by the provider, and so cannot be read from
+// the API, from another model.
+func (m *VPSResourceModel) keepConfiguredValues(from VPSResourceModel) {
+	m.Hostname = from.Hostname
+	m.WaitFor = from.WaitFor
+	m.VNCPasswordWOVersion = from.VNCPasswordWOVersion
+	m.SSHKeysVersion = from.SSHKeysVersion
+	m.DNSRecords = from.DNSRecords
+}
+
 func readServer(server mbVPS.Server) (*VPSResourceModel, diag.Diagnostics) {
 	var state VPSResourceModel
 	var diags diag.Diagnostics
@@ -612,6 +829,8 @@ func readServer(server mbVPS.Server) (*VPSResourceModel, diag.Diagnostics) {
 	state.Dormant = types.BoolValue(server.Dormant)
 	state.BootDevice = types.StringValue(server.BootDevice)
 	state.ISOImage = types.StringValue(server.ISOImage)
+	state.WaitFor = types.ObjectNull(vpsWaitForAttrTypes)
+	state.VNCPasswordWOVersion = types.Int64Null()
 
 	ipv4 := []attr.Value{}
 	for _, ip := range server.IPv4 {
@@ -665,20 +884,18 @@ func readServer(server mbVPS.Server) (*VPSResourceModel, diag.Diagnostics) {
 
 	vnc, d := types.ObjectValue(
 		map[string]attr.Type{
-			"mode":     types.StringType,
-			"password": types.StringType,
-			"ipv4":     types.StringType,
-			"ipv6":     types.StringType,
-			"port":     types.Int64Type,
-			"display":  types.Int64Type,
+			"mode":    types.StringType,
+			"ipv4":    types.StringType,
+			"ipv6":    types.StringType,
+			"port":    types.Int64Type,
+			"display": types.Int64Type,
 		},
 		map[string]attr.Value{
-			"mode":     types.StringValue(server.VNC.Mode),
-			"password": types.StringValue(server.VNC.Password),
-			"ipv4":     types.StringValue(server.VNC.IPv4),
-			"ipv6":     types.StringValue(server.VNC.IPv6),
-			"port":     types.Int64Value(server.VNC.Port),
-			"display":  types.Int64Value(server.VNC.Display),
+			"mode":    types.StringValue(server.VNC.Mode),
+			"ipv4":    types.StringValue(server.VNC.IPv4),
+			"ipv6":    types.StringValue(server.VNC.IPv6),
+			"port":    types.Int64Value(server.VNC.Port),
+			"display": types.Int64Value(server.VNC.Display),
 		},
 	)
 	diags = append(diags, d...)
@@ -730,6 +947,7 @@ func (r *VPSResource) Read(ctx context.Context, req resource.ReadRequest, resp *
 
 	server, d := readServer(data)
 	resp.Diagnostics.Append(d...)
+	server.keepConfiguredValues(state)
 
 	diags = resp.State.Set(ctx, *server)
 	resp.Diagnostics.Append(diags...)
@@ -738,6 +956,381 @@ func (r *VPSResource) Read(ctx context.Context, req resource.ReadRequest, resp *
 	}
 }
 
+// ModifyPlan adjusts the plan for changes that cannot be expressed in the schema alone.
+func (r *VPSResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to do on destroy, or before the provider is configured.
+	if req.Plan.Raw.IsNull() || r.client == nil {
+		return
+	}
+
+	var config VPSResourceModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var plan VPSResourceModel
+	diags = req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	creating := req.State.Raw.IsNull()
+
+	var state VPSResourceModel
+	if !creating {
+		diags = req.State.Get(ctx, &state)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		r.modifyPlanDiskSize(ctx, config, state, resp)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		r.modifyPlanZone(ctx, config, state, resp)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	r.modifyPlanCapacity(ctx, config, plan, state, creating, resp)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.modifyPlanISOImage(ctx, plan, state, resp)
+	if resp.Diagnostics.HasError() || creating {
+		return
+	}
+
+	r.modifyPlanIPv4(ctx, config, state, resp)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.modifyPlanDNS(ctx, config, state, resp)
+}
+
+// modifyPlanCapacity checks that a server placed on a private cloud host,
+// together with every other server planned on that host in this run, fits
+// in the host's free RAM and disk. Servers whose identifier is not known
+// yet are checked when the plan is made again during apply, as their
+// demand can't be told apart from that of other such servers.
+func (r *VPSResource) modifyPlanCapacity(ctx context.Context, config VPSResourceModel, plan VPSResourceModel, state VPSResourceModel, creating bool, resp *resource.ModifyPlanResponse) {
+	host := plan.HostServer.ValueString()
+	if plan.HostServer.IsUnknown() || host == "" || plan.Product.IsUnknown() || config.DiskSize.IsUnknown() || plan.Identifier.IsUnknown() {
+		return
+	}
+
+	capacity := vpsCapacityFor(r.client)
+	identifier := plan.Identifier.ValueString()
+
+	extraRAM, _ := specInt64FromSpecsObject(plan.Specs, "extra_ram")
+	extraCores, _ := specInt64FromSpecsObject(plan.Specs, "extra_cores")
+	disk := config.DiskSize.ValueInt64()
+
+	currentExtraRAM, _ := specInt64FromSpecsObject(state.Specs, "extra_ram")
+	currentDisk, _ := diskSizeFromSpecsObject(state.Specs)
+
+	// A server that is not growing cannot be the one to overcommit the host,
+	// and is checked without calling the API.
+	if !creating && plan.Product.Equal(state.Product) && extraRAM <= currentExtraRAM && disk <= currentDisk {
+		capacity.release(host, identifier)
+		return
+	}
+
+	products, err := r.client.VPS().GetProducts(ctx, "")
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to read Mythic Beasts VPS products",
+			err.Error(),
+		)
+		return
+	}
+
+	productSpecs := func(code string) mbVPS.ProductSpecs {
+		for _, product := range products {
+			if product.Code == code {
+				return product.Specs
+			}
+		}
+		return mbVPS.ProductSpecs{}
+	}
+
+	specs := productSpecs(plan.Product.ValueString())
+	ram := int64(specs.RAM) + extraRAM
+	cores := int64(specs.Cores) + extraCores
+
+	var demand vpsHostDemand
+	if creating {
+		demand = newVPSHostDemand(ram, disk, defaultVPSDiskType)
+	} else {
+		// The host's free capacity already excludes what the server uses,
+		// so only an increase needs to fit.
+		currentRAM := int64(productSpecs(state.Product.ValueString()).RAM) + currentExtraRAM
+
+		diskType := specStringFromSpecsObject(state.Specs, "disk_type")
+		if diskType == "" {
+			diskType = defaultVPSDiskType
+		}
+
+		demand = newVPSHostDemand(ram-currentRAM, disk-currentDisk, diskType)
+	}
+
+	total := capacity.plan(host, identifier, demand)
+
+	// A server moving to a product that isn't larger doesn't grow either.
+	if !creating && demand == (vpsHostDemand{}) {
+		return
+	}
+
+	hosts, err := r.client.VPS().GetHosts(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to read Mythic Beasts VPS hosts",
+			err.Error(),
+		)
+		return
+	}
+
+	for _, h := range hosts {
+		if h.Name != host {
+			continue
+		}
+
+		if err := checkHostCapacity(h, total, cores); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("host_server"),
+				"Insufficient private cloud host capacity",
+				err.Error()+". The total includes every server planned on this host in this run.",
+			)
+		}
+		return
+	}
+
+	resp.Diagnostics.AddAttributeError(
+		path.Root("host_server"),
+		"Unknown private cloud host",
+		fmt.Sprintf("The host server %q was not found; see the mythicbeasts_vps_hosts data source for valid values.", host),
+	)
+}
+
+// modifyPlanISOImage checks a changed iso_image against the available ISO
+// images, so that an invalid name fails the plan rather than the update
+// after the server has been powered off.
+func (r *VPSResource) modifyPlanISOImage(ctx context.Context, plan VPSResourceModel, state VPSResourceModel, resp *resource.ModifyPlanResponse) {
+	if plan.ISOImage.IsNull() || plan.ISOImage.IsUnknown() || plan.ISOImage.Equal(state.ISOImage) {
+		return
+	}
+
+	images, err := r.client.VPS().GetISOImages(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to read Mythic Beasts VPS ISO images",
+			err.Error(),
+		)
+		return
+	}
+
+	names := make([]string, 0, len(images))
+	for _, image := range images {
+		if image.Name == plan.ISOImage.ValueString() {
+			return
+		}
+		names = append(names, image.Name)
+	}
+
+	resp.Diagnostics.AddAttributeError(
+		path.Root("iso_image"),
+		"Invalid VPS ISO image",
+		fmt.Sprintf("%q is not an available ISO image. Valid values are: %s. See the mythicbeasts_vps_iso_images data source.", plan.ISOImage.ValueString(), strings.Join(names, ", ")),
+	)
+}
+
+// lockHost serialises creates and spec updates on a private cloud host.
+// The returned function releases the lock and the server's planned demand,
+// which the host's free capacity includes once the change is applied.
+func (r *VPSResource) lockHost(host string, identifier string) func() {
+	if host == "" {
+		return func() {}
+	}
+
+	capacity := vpsCapacityFor(r.client)
+	unlock := capacity.lock(host)
+
+	return func() {
+		capacity.release(host, identifier)
+		unlock()
+	}
+}
+
+// modifyPlanZone replaces the server when the write-only create_in_zone
+// no longer covers the zone the server is in. A configured zone covers its
+// own code and every zone that has it as a parent, such as `uk` covering
+// the London and Cambridge datacentres.
+func (r *VPSResource) modifyPlanZone(ctx context.Context, config VPSResourceModel, state VPSResourceModel, resp *resource.ModifyPlanResponse) {
+	if config.CreateInZone.IsNull() || config.CreateInZone.IsUnknown() || state.Zone.IsNull() || state.Zone.IsUnknown() {
+		return
+	}
+
+	code, ok := state.Zone.Attributes()["code"].(types.String)
+	if !ok || code.IsNull() || code.IsUnknown() {
+		return
+	}
+
+	configured := config.CreateInZone.ValueString()
+	if code.ValueString() == configured {
+		return
+	}
+
+	zones, err := r.client.VPS().GetZones(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to read Mythic Beasts VPS zones",
+			err.Error(),
+		)
+		return
+	}
+
+	if !slices.ContainsFunc(zones, func(zone mbVPS.ZoneInfo) bool { return zone.Name == configured }) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("create_in_zone"),
+			"Unknown VPS zone",
+			fmt.Sprintf("The zone %q was not found; see the mythicbeasts_vps_zones data source for valid values.", configured),
+		)
+		return
+	}
+
+	if zoneWithin(code.ValueString(), configured, zones) {
+		return
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("VPS %s is in zone %s, not %s, and will be replaced", state.Identifier.ValueString(), code.ValueString(), configured))
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("zone"), types.ObjectUnknown(state.Zone.AttributeTypes(ctx)))...)
+	resp.RequiresReplace = append(resp.RequiresReplace, path.Root("zone"))
+}
+
+// zoneWithin reports whether the zone code is the wanted zone or one of
+// its descendants, following the parents of each zone.
+func zoneWithin(code string, want string, zones []mbVPS.ZoneInfo) bool {
+	parents := make(map[string][]string, len(zones))
+	for _, zone := range zones {
+		parents[zone.Name] = zone.Parents
+	}
+
+	seen := map[string]bool{}
+	queue := []string{code}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		if current == want {
+			return true
+		}
+		if seen[current] {
+			continue
+		}
+		seen[current] = true
+
+		queue = append(queue, parents[current]...)
+	}
+
+	return false
+}
+
+// modifyPlanDiskSize rejects a change to the write-only disk_size that
+// would shrink the disk, or that is not offered for the server's disk type,
+// before the API rejects it part way through an apply.
+func (r *VPSResource) modifyPlanDiskSize(ctx context.Context, config VPSResourceModel, state VPSResourceModel, resp *resource.ModifyPlanResponse) {
+	if config.DiskSize.IsNull() || config.DiskSize.IsUnknown() {
+		return
+	}
+
+	desired := config.DiskSize.ValueInt64()
+	current, ok := diskSizeFromSpecsObject(state.Specs)
+	if !ok || desired == current {
+		return
+	}
+
+	if desired < current {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("disk_size"),
+			"VPS disk cannot be shrunk",
+			fmt.Sprintf("The disk of VPS %s is %d MB and cannot be reduced to %d MB. Set disk_size to %d or larger, or replace the server.", state.Identifier.ValueString(), current, desired, current),
+		)
+		return
+	}
+
+	diskType := specStringFromSpecsObject(state.Specs, "disk_type")
+
+	sizes, err := r.client.VPS().GetDiskSizes(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to read Mythic Beasts VPS disk sizes",
+			err.Error(),
+		)
+		return
+	}
+
+	var valid []int64
+	switch strings.ToLower(diskType) {
+	case "ssd":
+		valid = sizes.SSD
+	case "hdd":
+		valid = sizes.HDD
+	default:
+		tflog.Debug(ctx, "Unknown VPS disk type, not checking disk size", map[string]interface{}{
+			"disk_type": diskType,
+		})
+		return
+	}
+
+	if slices.Contains(valid, desired) {
+		return
+	}
+
+	resp.Diagnostics.AddAttributeError(
+		path.Root("disk_size"),
+		"Invalid VPS disk size",
+		fmt.Sprintf("%d MB is not a valid size for a %s disk. Valid sizes are: %s. See the mythicbeasts_vps_disk_sizes data source.", desired, diskType, joinInt64s(valid)),
+	)
+}
+
+func joinInt64s(values []int64) string {
+	parts := make([]string, 0, len(values))
+	for _, value := range values {
+		parts = append(parts, strconv.FormatInt(value, 10))
+	}
+
+	return strings.Join(parts, ", ")
+}
+
+// modifyPlanIPv4 detects a change to the write-only ipv4_enabled by
+// comparing it with the addresses the server currently has, and marks the
+// values that will change when IPv4 is allocated or released as unknown.
+func (r *VPSResource) modifyPlanIPv4(ctx context.Context, config VPSResourceModel, state VPSResourceModel, resp *resource.ModifyPlanResponse) {
+	if config.IPv4Enabled.IsNull() || config.IPv4Enabled.IsUnknown() {
+		return
+	}
+
+	if config.IPv4Enabled.ValueBool() == hasIPv4(state.IPv4) {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("ipv4"), types.SetUnknown(types.StringType))...)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("price"), types.Float64Unknown())...)
+}
+
+func hasIPv4(ipv4 types.Set) bool {
+	return !ipv4.IsNull() && !ipv4.IsUnknown() && len(ipv4.Elements()) > 0
+}
+
 // Update updates the resource and sets the updated Terraform state on success.
 func (r *VPSResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
 	var plan VPSResourceModel
@@ -799,6 +1392,12 @@ func (r *VPSResource) Update(ctx context.Context, req resource.UpdateRequest, re
 		hasUpdate = true
 	}
 
+	if !config.VNCPasswordWO.IsNull() && !config.VNCPasswordWO.IsUnknown() &&
+		!plan.VNCPasswordWOVersion.Equal(state.VNCPasswordWOVersion) {
+		updateReq.SetVNCPassword(config.VNCPasswordWO.ValueString())
+		hasUpdate = true
+	}
+
 	specsUpdate := mbVPS.NewUpdateSpecs()
 	hasSpecsUpdate := false
 
@@ -850,6 +1449,11 @@ func (r *VPSResource) Update(ctx context.Context, req resource.UpdateRequest, re
 		}
 	}
 
+	if hasSpecsUpdate || plan.Product.ValueString() != state.Product.ValueString() {
+		unlockHost := r.lockHost(state.HostServer.ValueString(), state.Identifier.ValueString())
+		defer unlockHost()
+	}
+
 	if hasUpdate {
 		requiresPoweredOff := updateReq.RequiresPoweredOff()
 		shouldPowerOnAfter := false
@@ -905,6 +1509,13 @@ func (r *VPSResource) Update(ctx context.Context, req resource.UpdateRequest, re
 		}
 	}
 
+	if !config.IPv4Enabled.IsNull() && !config.IPv4Enabled.IsUnknown() {
+		r.updateIPv4(ctx, state, config.IPv4Enabled.ValueBool(), resp)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
 	updated, err := r.client.VPS().Get(ctx, state.Identifier.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -919,6 +1530,12 @@ func (r *VPSResource) Update(ctx context.Context, req resource.UpdateRequest, re
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	server.keepConfiguredValues(plan)
+
+	r.updateDNS(ctx, server, state, config, resp)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	diags = resp.State.Set(ctx, *server)
 	resp.Diagnostics.Append(diags...)
@@ -927,10 +1544,93 @@ func (r *VPSResource) Update(ctx context.Context, req resource.UpdateRequest, re
 	}
 }
 
+// updateDNS sets and removes records so that the provider managed DNS
+// matches the hostname, DNS flags and addresses of the updated server.
+func (r *VPSResource) updateDNS(ctx context.Context, server *VPSResourceModel, state VPSResourceModel, config VPSResourceModel, resp *resource.UpdateResponse) {
+	desired, diags := vpsDNSRecordsForModel(ctx, *server, config.Hostname, config.SetForwardDNS, config.SetReverseDNS)
+	resp.Diagnostics.Append(diags...)
+
+	current, diags := vpsDNSRecordsFromValue(ctx, state.DNSRecords)
+	resp.Diagnostics.Append(diags...)
+
+	ipv4, diags := stringsFromSet(ctx, server.IPv4)
+	resp.Diagnostics.Append(diags...)
+	ipv6, diags := stringsFromSet(ctx, server.IPv6)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := reconcileVPSDNSRecords(ctx, r.client, state.Identifier.ValueString(), append(ipv4, ipv6...), current, desired)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating VPS DNS records",
+			"Could not update DNS records for VPS "+state.Identifier.String()+": "+err.Error(),
+		)
+		return
+	}
+
+	server.DNSRecords, diags = vpsDNSRecordsValue(desired)
+	resp.Diagnostics.Append(diags...)
+}
+
+// updateIPv4 allocates or releases IPv4 addresses so that the server
+// matches the configured ipv4_enabled.
+func (r *VPSResource) updateIPv4(ctx context.Context, state VPSResourceModel, enabled bool, resp *resource.UpdateResponse) {
+	identifier := state.Identifier.ValueString()
+
+	if enabled && !hasIPv4(state.IPv4) {
+		tflog.Info(ctx, fmt.Sprintf("allocating IPv4 address for VPS %s", identifier))
+
+		if _, err := r.client.VPS().AllocateIPv4(ctx, identifier); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("ipv4_enabled"),
+				"Error allocating VPS IPv4 address",
+				"Could not allocate an IPv4 address for VPS "+state.Identifier.String()+": "+err.Error(),
+			)
+		}
+		return
+	}
+
+	if !enabled && hasIPv4(state.IPv4) {
+		var addresses []string
+		resp.Diagnostics.Append(state.IPv4.ElementsAs(ctx, &addresses, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		for _, address := range addresses {
+			tflog.Info(ctx, fmt.Sprintf("releasing IPv4 address %s from VPS %s", address, identifier))
+
+			if err := r.client.VPS().ReleaseIPv4(ctx, identifier, address); err != nil {
+				resp.Diagnostics.AddAttributeError(
+					path.Root("ipv4_enabled"),
+					"Error releasing VPS IPv4 address",
+					fmt.Sprintf("Could not release IPv4 address %s from VPS %s: %s", address, state.Identifier.String(), err.Error()),
+				)
+				return
+			}
+		}
+	}
+}
+
 func diskSizeFromSpecsObject(specs types.Object) (int64, bool) {
 	return specInt64FromSpecsObject(specs, "disk_size")
 }
 
+func specStringFromSpecsObject(specs types.Object, key string) string {
+	if specs.IsNull() || specs.IsUnknown() {
+		return ""
+	}
+
+	specAttr, ok := specs.Attributes()[key].(types.String)
+	if !ok || specAttr.IsNull() || specAttr.IsUnknown() {
+		return ""
+	}
+
+	return specAttr.ValueString()
+}
+
 func specInt64FromSpecsObject(specs types.Object, key string) (int64, bool) {
 	if specs.IsNull() || specs.IsUnknown() {
 		return 0, false
@@ -958,6 +1658,23 @@ func (r *VPSResource) Delete(ctx context.Context, req resource.DeleteRequest, re
 		return
 	}
 
+	records, diags := vpsDNSRecordsFromValue(ctx, state.DNSRecords)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Remove the records before the server so that its addresses are
+	// still assigned; a failure leaves a warning rather than the server.
+	for _, record := range records {
+		if err := deleteVPSDNSRecord(ctx, r.client, state.Identifier.ValueString(), record); err != nil {
+			resp.Diagnostics.AddWarning(
+				"Error removing VPS DNS record",
+				fmt.Sprintf("Could not remove %s record %s -> %s for VPS %s, it may need to be removed manually: %s", record.Type, record.Name, record.Value, state.Identifier.String(), err.Error()),
+			)
+		}
+	}
+
 	err := r.client.VPS().Delete(ctx, state.Identifier.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
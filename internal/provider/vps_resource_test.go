@@ -5,10 +5,12 @@ package provider
 
 import (
 	"fmt"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
 	"github.com/hashicorp/terraform-plugin-testing/statecheck"
 	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
 )
@@ -23,7 +25,7 @@ func TestAccVPSResource(t *testing.T) {
 		Steps: []resource.TestStep{
 			// Create and Read testing
 			{
-				Config: testAccVPSResourceConfig(identifier),
+				Config: testAccVPSResourceConfig(identifier, 10240, false, false, 0),
 				ConfigStateChecks: []statecheck.StateCheck{
 					statecheck.ExpectKnownValue(
 						resourceAddress,
@@ -35,6 +37,15 @@ func TestAccVPSResource(t *testing.T) {
 						tfjsonpath.New("cpu_mode"),
 						knownvalue.StringExact("performance"),
 					),
+					statecheck.ExpectKnownValue(
+						resourceAddress,
+						tfjsonpath.New("wait_for"),
+						knownvalue.ObjectExact(map[string]knownvalue.Check{
+							"status":   knownvalue.StringExact("running"),
+							"tcp_port": knownvalue.Null(),
+							"timeout":  knownvalue.StringExact("10m"),
+						}),
+					),
 					statecheck.ExpectKnownValue(
 						resourceAddress,
 						tfjsonpath.New("zone"),
@@ -61,10 +72,14 @@ func TestAccVPSResource(t *testing.T) {
 				ImportStateVerify:                    true,
 				ImportStateId:                        identifier,
 				ImportStateVerifyIdentifierAttribute: "identifier",
+				ImportStateVerifyIgnore: []string{
+					"wait_for",
+					"dns_records",
+				},
 			},
 			// Update and Read testing
 			{
-				Config: testAccVPSResourceConfig(identifier),
+				Config: testAccVPSResourceConfig(identifier, 10240, false, false, 0),
 				ConfigStateChecks: []statecheck.StateCheck{
 					statecheck.ExpectKnownValue(
 						resourceAddress,
@@ -100,22 +115,101 @@ func TestAccVPSResource(t *testing.T) {
 					),
 				},
 			},
+			// Enable IPv4 in place
+			{
+				Config: testAccVPSResourceConfig(identifier, 10240, true, false, 0),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction(resourceAddress, plancheck.ResourceActionUpdate),
+					},
+				},
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						resourceAddress,
+						tfjsonpath.New("ipv4"),
+						knownvalue.SetSizeExact(1),
+					),
+				},
+			},
+			// Set reverse DNS in place
+			{
+				Config: testAccVPSResourceConfig(identifier, 10240, true, true, 0),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction(resourceAddress, plancheck.ResourceActionUpdate),
+					},
+				},
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						resourceAddress,
+						tfjsonpath.New("dns_records"),
+						knownvalue.SetSizeExact(2),
+					),
+				},
+			},
+			// Setting ssh_keys_version for the first time doesn't replace the server
+			{
+				Config: testAccVPSResourceConfig(identifier, 10240, true, true, 1),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction(resourceAddress, plancheck.ResourceActionUpdate),
+					},
+				},
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						resourceAddress,
+						tfjsonpath.New("ssh_keys_version"),
+						knownvalue.Int64Exact(1),
+					),
+				},
+			},
+			// Removing ssh_keys_version doesn't replace the server
+			{
+				Config: testAccVPSResourceConfig(identifier, 10240, true, true, 0),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction(resourceAddress, plancheck.ResourceActionUpdate),
+					},
+				},
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						resourceAddress,
+						tfjsonpath.New("ssh_keys_version"),
+						knownvalue.Null(),
+					),
+				},
+			},
+			// Shrinking the disk is rejected at plan time
+			{
+				Config:      testAccVPSResourceConfig(identifier, 5120, true, true, 0),
+				ExpectError: regexp.MustCompile("VPS disk cannot be shrunk"),
+			},
 			// Delete testing automatically occurs in TestCase
 		},
 	})
 }
 
-func testAccVPSResourceConfig(identifier string) string {
+func testAccVPSResourceConfig(identifier string, diskSize int, ipv4Enabled bool, reverseDNS bool, sshKeysVersion int) string {
+	version := ""
+	if sshKeysVersion > 0 {
+		version = fmt.Sprintf("\n  ssh_keys_version = %d", sshKeysVersion)
+	}
+
 	return fmt.Sprintf(`
 resource "mythicbeasts_vps" %[1]q {
-  identifier     = %[1]q
-  name           = %[1]q
-  disk_size      = 10240
-  image          = "cloudinit-ubuntu-noble.raw.gz"
-  ipv4_enabled   = false
-  product        = "VPSX4"
-  ssh_keys       = "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIPfx70ArvHPF+9U3GgKgNEAWkXSyZMun83sn9582Pl4e code@paultibbetts.uk"
-  create_in_zone = "uk"
+  identifier      = %[1]q
+  name            = %[1]q
+  disk_size       = %[2]d
+  image           = "cloudinit-ubuntu-noble.raw.gz"
+  ipv4_enabled    = %[3]t
+  set_reverse_dns = %[4]t
+  product         = "VPSX4"
+  ssh_keys        = "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIPfx70ArvHPF+9U3GgKgNEAWkXSyZMun83sn9582Pl4e code@paultibbetts.uk"
+  create_in_zone  = "uk"%[5]s
+
+  wait_for = {
+    status = "running"
+  }
 }
-`, identifier)
+`, identifier, diskSize, ipv4Enabled, reverseDNS, version)
 }
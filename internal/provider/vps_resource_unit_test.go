@@ -0,0 +1,70 @@
+// Copyright IBM Corp. 2021, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	mbVPS "github.com/paultibbetts/mythicbeasts-client-go/vps"
+)
+
+func TestZoneWithin(t *testing.T) {
+	zones := []mbVPS.ZoneInfo{
+		{Name: "uk"},
+		{Name: "london", Parents: []string{"uk"}},
+		{Name: "hex", Parents: []string{"london"}},
+		{Name: "cam", Parents: []string{"uk"}},
+		{Name: "ams", Parents: []string{"nl"}},
+		{Name: "nl"},
+	}
+
+	cases := []struct {
+		code string
+		want string
+		in   bool
+	}{
+		{code: "hex", want: "hex", in: true},
+		{code: "hex", want: "london", in: true},
+		{code: "hex", want: "uk", in: true},
+		{code: "cam", want: "london", in: false},
+		{code: "ams", want: "uk", in: false},
+		{code: "unknown", want: "uk", in: false},
+	}
+
+	for _, c := range cases {
+		if got := zoneWithin(c.code, c.want, zones); got != c.in {
+			t.Errorf("zoneWithin(%q, %q) = %t, want %t", c.code, c.want, got, c.in)
+		}
+	}
+}
+
+func TestRequiresReplaceIfVersionChanged(t *testing.T) {
+	cases := []struct {
+		name  string
+		state types.Int64
+		plan  types.Int64
+		want  bool
+	}{
+		{name: "first set", state: types.Int64Null(), plan: types.Int64Value(1), want: false},
+		{name: "changed", state: types.Int64Value(1), plan: types.Int64Value(2), want: true},
+		{name: "removed", state: types.Int64Value(1), plan: types.Int64Null(), want: false},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			req := planmodifier.Int64Request{StateValue: c.state, PlanValue: c.plan, ConfigValue: c.plan}
+			resp := &int64planmodifier.RequiresReplaceIfFuncResponse{}
+
+			requiresReplaceIfVersionChanged(context.Background(), req, resp)
+
+			if resp.RequiresReplace != c.want {
+				t.Errorf("RequiresReplace = %t, want %t", resp.RequiresReplace, c.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,114 @@
+// Copyright IBM Corp. 2021, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	defaultVPSWaitForStatus  = "running"
+	defaultVPSWaitForTimeout = "10m"
+	vpsWaitPollInterval      = 5 * time.Second
+)
+
+// VPSWaitForModel maps the wait_for attribute of the VPS resource.
+type VPSWaitForModel struct {
+	Status  types.String `tfsdk:"status"`
+	TCPPort types.Int64  `tfsdk:"tcp_port"`
+	Timeout types.String `tfsdk:"timeout"`
+}
+
+var vpsWaitForAttrTypes = map[string]attr.Type{
+	"status":   types.StringType,
+	"tcp_port": types.Int64Type,
+	"timeout":  types.StringType,
+}
+
+// waitForStatus polls getStatus until it reports the wanted status, the
+// context is cancelled or getStatus returns an error.
+func waitForStatus(ctx context.Context, getStatus func(context.Context) (string, error), want string, interval time.Duration) error {
+	for {
+		status, err := getStatus(ctx)
+		if err != nil {
+			return err
+		}
+
+		if strings.EqualFold(status, want) {
+			return nil
+		}
+
+		tflog.Debug(ctx, "Waiting for server status", map[string]interface{}{
+			"current": status,
+			"want":    want,
+		})
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("timed out waiting for status %q, last status was %q", want, status)
+		case <-time.After(interval):
+		}
+	}
+}
+
+// waitForTCP repeatedly tries to open a TCP connection to each of the
+// addresses until one of them accepts or the context is cancelled.
+func waitForTCP(ctx context.Context, addresses []string, interval time.Duration) (string, error) {
+	if len(addresses) == 0 {
+		return "", fmt.Errorf("no addresses to connect to")
+	}
+
+	var dialer net.Dialer
+	var lastErr error
+
+	for {
+		for _, address := range addresses {
+			attemptCtx, cancel := context.WithTimeout(ctx, interval)
+			conn, err := dialer.DialContext(attemptCtx, "tcp", address)
+			cancel()
+			if err == nil {
+				_ = conn.Close()
+				return address, nil
+			}
+
+			lastErr = err
+			tflog.Debug(ctx, "Waiting for TCP connection", map[string]interface{}{
+				"address": address,
+				"error":   err.Error(),
+			})
+		}
+
+		select {
+		case <-ctx.Done():
+			return "", fmt.Errorf("timed out waiting for a TCP connection to %s: %w", strings.Join(addresses, ", "), lastErr)
+		case <-time.After(interval):
+		}
+	}
+}
+
+// vpsTCPAddresses returns the host:port pairs to try when waiting for a
+// TCP port on a VPS. The IPv6 addresses are tried first, followed by the
+// SSH proxy when waiting for SSH, as the machine running Terraform may not
+// have IPv6 connectivity.
+func vpsTCPAddresses(ipv6 []string, port int64, sshProxyHostname string, sshProxyPort int64) []string {
+	addresses := make([]string, 0, len(ipv6)+1)
+	for _, ip := range ipv6 {
+		addresses = append(addresses, net.JoinHostPort(ip, strconv.FormatInt(port, 10)))
+	}
+
+	if port == 22 && sshProxyHostname != "" && sshProxyPort != 0 {
+		addresses = append(addresses, net.JoinHostPort(sshProxyHostname, strconv.FormatInt(sshProxyPort, 10)))
+	}
+
+	return addresses
+}
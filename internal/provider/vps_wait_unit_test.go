@@ -0,0 +1,128 @@
+// Copyright IBM Corp. 2021, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+)
+
+func TestWaitForStatusReachesWantedStatus(t *testing.T) {
+	statuses := []string{"installing", "stopped", "Running"}
+	calls := 0
+
+	getStatus := func(_ context.Context) (string, error) {
+		status := statuses[calls]
+		calls++
+		return status, nil
+	}
+
+	if err := waitForStatus(context.Background(), getStatus, "running", time.Millisecond); err != nil {
+		t.Fatalf("expected no error, got %s", err)
+	}
+
+	if calls != len(statuses) {
+		t.Fatalf("expected %d status checks, got %d", len(statuses), calls)
+	}
+}
+
+func TestWaitForStatusTimesOut(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	getStatus := func(_ context.Context) (string, error) {
+		return "installing", nil
+	}
+
+	if err := waitForStatus(ctx, getStatus, "running", time.Millisecond); err == nil {
+		t.Fatalf("expected a timeout error")
+	}
+}
+
+func TestWaitForStatusReturnsGetError(t *testing.T) {
+	wantErr := errors.New("boom")
+
+	getStatus := func(_ context.Context) (string, error) {
+		return "", wantErr
+	}
+
+	if err := waitForStatus(context.Background(), getStatus, "running", time.Millisecond); !errors.Is(err, wantErr) {
+		t.Fatalf("expected %v, got %v", wantErr, err)
+	}
+}
+
+func TestWaitForTCPConnectsToLocalListener(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("could not start listener: %s", err)
+	}
+	defer listener.Close()
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			_ = conn.Close()
+		}
+	}()
+
+	closed, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("could not reserve a closed port: %s", err)
+	}
+	closedAddress := closed.Addr().String()
+	_ = closed.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	address, err := waitForTCP(ctx, []string{closedAddress, listener.Addr().String()}, 10*time.Millisecond)
+	if err != nil {
+		t.Fatalf("expected no error, got %s", err)
+	}
+
+	if address != listener.Addr().String() {
+		t.Fatalf("expected connection to %s, got %s", listener.Addr().String(), address)
+	}
+}
+
+func TestWaitForTCPTimesOut(t *testing.T) {
+	closed, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("could not reserve a closed port: %s", err)
+	}
+	closedAddress := closed.Addr().String()
+	_ = closed.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	if _, err := waitForTCP(ctx, []string{closedAddress}, 10*time.Millisecond); err == nil {
+		t.Fatalf("expected a timeout error")
+	}
+}
+
+func TestVPSTCPAddresses(t *testing.T) {
+	got := vpsTCPAddresses([]string{"2a00:1098::1"}, 22, "vs-example.mythic-beasts.com", 4022)
+	want := []string{"[2a00:1098::1]:22", "vs-example.mythic-beasts.com:4022"}
+
+	if len(got) != len(want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("expected %v, got %v", want, got)
+		}
+	}
+
+	got = vpsTCPAddresses([]string{"2a00:1098::1"}, 80, "vs-example.mythic-beasts.com", 4022)
+	if len(got) != 1 || got[0] != "[2a00:1098::1]:80" {
+		t.Fatalf("expected only the IPv6 address for a non-SSH port, got %v", got)
+	}
+}